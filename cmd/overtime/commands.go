@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/internal/config"
+	"github.com/MateSousa/overtime-script/pkg/adapters/importers"
+	"github.com/MateSousa/overtime-script/pkg/adapters/notification"
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/usecases"
+)
+
+// runCommand runs a subcommand given on the command line
+func runCommand(ctx context.Context, cfg *config.Config, uc *usecases.OvertimeUseCase, notifier *notification.MultiNotificationService, name string, args []string) error {
+	switch name {
+	case "resend":
+		return runResend(ctx, uc, notifier, args)
+	case "approve":
+		return runApprove(ctx, cfg, uc, args)
+	case "reject":
+		return runReject(ctx, cfg, uc, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// isLocalCommand reports whether a subcommand runs on a workstation, reaching the cluster
+// through the kubeconfig of the user when it needs the stored entries
+func isLocalCommand(name string) bool {
+	return name == "import" || name == "suggest"
+}
+
+// runLocalCommand runs a subcommand that sends no notifications
+func runLocalCommand(ctx context.Context, cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "import":
+		return runImport(ctx, cfg, args)
+	case "suggest":
+		return runSuggest(ctx, cfg, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// runResend sends the report of a month again, ignoring its delivery record
+func runResend(ctx context.Context, uc *usecases.OvertimeUseCase, notifier *notification.MultiNotificationService, args []string) error {
+	flags := flag.NewFlagSet("resend", flag.ContinueOnError)
+	month := flags.String("month", "", "month to resend, as Sep-2026 or 2026-09")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *month == "" {
+		return fmt.Errorf("--month is required")
+	}
+
+	fmt.Printf("Resending the report of %s...\n", *month)
+	err := uc.ResendMonthlyReport(ctx, *month)
+	printDeliveryResults(notifier)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Monthly report sent successfully!")
+	return nil
+}
+
+// runApprove approves the entries of a ConfigMap for the monthly report
+func runApprove(ctx context.Context, cfg *config.Config, uc *usecases.OvertimeUseCase, args []string) error {
+	flags := flag.NewFlagSet("approve", flag.ContinueOnError)
+	entry := flags.String("entry", "", "name of the ConfigMap holding the entries")
+	approver := flags.String("by", cfg.ApproverName, "name of the approver, APPROVER_NAME by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *entry == "" {
+		return fmt.Errorf("--entry is required")
+	}
+
+	if err := uc.ApproveEntries(ctx, *entry, *approver); err != nil {
+		return err
+	}
+	fmt.Printf("Entries of %s approved by %s\n", *entry, *approver)
+	return nil
+}
+
+// runReject leaves the entries of a ConfigMap out of the monthly report
+func runReject(ctx context.Context, cfg *config.Config, uc *usecases.OvertimeUseCase, args []string) error {
+	flags := flag.NewFlagSet("reject", flag.ContinueOnError)
+	entry := flags.String("entry", "", "name of the ConfigMap holding the entries")
+	approver := flags.String("by", cfg.ApproverName, "name of the approver, APPROVER_NAME by default")
+	reason := flags.String("reason", "", "why the entries are rejected")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *entry == "" {
+		return fmt.Errorf("--entry is required")
+	}
+
+	if err := uc.RejectEntries(ctx, *entry, *approver, *reason); err != nil {
+		return err
+	}
+	fmt.Printf("Entries of %s rejected by %s\n", *entry, *approver)
+	return nil
+}
+
+// runImport previews the overtime found in a Toggl or Clockify export, and saves it with --apply
+func runImport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := flags.String("file", "", "Toggl or Clockify export, a detailed CSV report or JSON time entries, or an iCalendar .ics file")
+	from := flags.String("from", "", "first day of the entries to import, as YYYY-MM-DD")
+	to := flags.String("to", "", "last day of the entries to import, as YYYY-MM-DD")
+	outsideHours := flags.Bool("outside-hours", false, "count only the time of calendar events outside the working hours")
+	owner := flags.String("owner", cfg.OvertimeOwner, "owner of the entries exported without a user, OVERTIME_OWNER by default")
+	dateLayout := flags.String("date-layout", "", "Go layout of the CSV dates when they are not ISO dates, 01/02/2006 by default")
+	apply := flags.Bool("apply", false, "save the entries instead of only previewing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("--file is required")
+	}
+
+	export, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", *file, err)
+	}
+	defer export.Close()
+
+	options := importers.TrackerOptions{
+		Location:   cfg.WorkingHours.Location,
+		DateLayout: *dateLayout,
+	}
+	if options.From, err = parseDay("--from", *from, options.Location); err != nil {
+		return err
+	}
+	if options.To, err = parseDay("--to", *to, options.Location); err != nil {
+		return err
+	}
+	if !options.To.IsZero() {
+		options.To = options.To.AddDate(0, 0, 1)
+	}
+	tracked, skipped, err := importers.ParseTrackerExport(export, *file, options)
+	if err != nil {
+		return err
+	}
+	// Calendar events such as on-call interventions are overtime as a whole
+	calendar := strings.EqualFold(filepath.Ext(*file), ".ics")
+
+	rules := entities.ImportRules{
+		Tickets:      cfg.ImportTicketMap,
+		OvertimeTags: cfg.ImportOvertimeTags,
+		WorkingHours: cfg.WorkingHours,
+		AllOvertime:  calendar && !*outsideHours,
+		JiraBaseURL:  cfg.JiraBaseURL,
+		Owner:        *owner,
+	}
+	// Previews read the imported entries too, to leave out the entries already imported
+	uc, err := newLocalUseCase(cfg, true)
+	if err != nil {
+		return err
+	}
+	result, err := uc.ImportEntries(ctx, tracked, rules, *apply)
+	if result != nil {
+		result.Skipped = append(skipped, result.Skipped...)
+	}
+	printImportResult(result)
+	if err != nil {
+		return err
+	}
+
+	if !*apply {
+		fmt.Println("Preview only, run again with --apply to save the entries")
+	} else if result.Source != "" {
+		fmt.Printf("Saved %d entries in %s\n", len(result.Entries), result.Source)
+	}
+	return nil
+}
+
+// runSuggest proposes overtime entries from the commits of an author outside the working
+// hours. It reads local git repositories, so it runs on the workstation holding them, with
+// git installed; only --compare reaches the cluster.
+func runSuggest(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("suggest", flag.ContinueOnError)
+	repos := flags.String("repos", ".", "comma separated git repositories, or directories holding them")
+	author := flags.String("author", cfg.OvertimeOwner, "author name or email of the commits, OVERTIME_OWNER by default")
+	month := flags.String("month", entities.PeriodFor(time.Now()), "month to scan, as Sep-2026 or 2026-09")
+	compare := flags.Bool("compare", false, "compare the proposal with the entries logged in the month")
+	gap := flags.Duration("gap", entities.DefaultSessionGap, "longest pause between the commits of a session")
+	lead := flags.Duration("lead", entities.DefaultLeadTime, "work assumed before the first commit of a session")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *author == "" {
+		return fmt.Errorf("--author is required")
+	}
+
+	monthStart, err := entities.ParsePeriod(*month)
+	if err != nil {
+		return err
+	}
+	location := cfg.WorkingHours.Location
+	if location == nil {
+		location = time.UTC
+	}
+	since := time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, location)
+
+	repositories, err := importers.FindGitRepositories(strings.Split(*repos, ","))
+	if err != nil {
+		return err
+	}
+	if len(repositories) == 0 {
+		return fmt.Errorf("no git repositories found in %s", *repos)
+	}
+	commits, err := importers.ReadGitCommits(ctx, repositories, *author, since, since.AddDate(0, 1, 0))
+	if err != nil {
+		return err
+	}
+
+	rules := entities.SuggestRules{
+		WorkingHours: cfg.WorkingHours,
+		SessionGap:   *gap,
+		LeadTime:     *lead,
+		JiraBaseURL:  cfg.JiraBaseURL,
+	}
+	uc, err := newLocalUseCase(cfg, *compare)
+	if err != nil {
+		return err
+	}
+	suggestion, err := uc.SuggestOvertime(ctx, commits, rules, *author, entities.PeriodFor(since), *compare)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d commits in %d repositories, %d sessions outside working hours:\n", len(commits), len(repositories), len(suggestion.Sessions))
+	for _, session := range suggestion.Sessions {
+		fmt.Printf("  %s-%s %4d min  %s\n", session.Start.In(location).Format("2006-01-02 15:04"), session.End.In(location).Format("15:04"), session.Minutes, session.Description())
+	}
+	fmt.Println("Proposed entries:")
+	for _, entry := range suggestion.Entries {
+		ticket := entry.TicketURL
+		if ticket == "" {
+			ticket = "(no ticket)"
+		}
+		fmt.Printf("  %s %4d min  %s  %s\n", entry.Date.Format("2006-01-02"), entry.Minutes, ticket, entry.Description)
+	}
+	if *compare {
+		fmt.Println("Suggested and logged minutes by day:")
+		for _, day := range suggestion.Days {
+			fmt.Printf("  %s  suggested %4d  logged %4d  difference %+d\n", day.Date.Format("2006-01-02"), day.Suggested, day.Logged, day.Difference())
+		}
+	}
+	return nil
+}
+
+// parseDay parses a YYYY-MM-DD flag, zero when empty
+func parseDay(name, value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", name, value)
+	}
+	return day, nil
+}
+
+// printImportResult lists the entries found in an export and the skipped time
+func printImportResult(result *entities.ImportResult) {
+	if result == nil {
+		return
+	}
+	fmt.Printf("%d overtime entries, %d minutes:\n", len(result.Entries), result.TotalMinutes())
+	for _, entry := range result.Entries {
+		fmt.Printf("  %s %4d min  %s  %s  %s\n", entry.Date.Format("2006-01-02"), entry.Minutes, entry.TicketURL, entry.Owner, entry.Description)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("%d skipped:\n", len(result.Skipped))
+		for _, skipped := range result.Skipped {
+			fmt.Printf("  %s\n", skipped)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/MateSousa/overtime-script/pkg/adapters/exporters"
 	"github.com/MateSousa/overtime-script/pkg/adapters/notification"
 	"github.com/MateSousa/overtime-script/pkg/adapters/repositories"
+	domainrepos "github.com/MateSousa/overtime-script/pkg/domain/repositories"
 	"github.com/MateSousa/overtime-script/pkg/domain/usecases"
 	"github.com/MateSousa/overtime-script/pkg/infrastructure/kubernetes"
 )
@@ -18,6 +20,20 @@ func main() {
 	// Create context
 	ctx := context.Background()
 
+	// Local commands run on a workstation, without in-cluster access or notification settings
+	if len(os.Args) > 1 && isLocalCommand(os.Args[1]) {
+		cfg, err := config.LoadLocalConfig()
+		if err != nil {
+			fmt.Printf("Error loading configuration: %v\n", err)
+			os.Exit(1)
+		}
+		if err := runLocalCommand(ctx, cfg, os.Args[1], os.Args[2:]); err != nil {
+			fmt.Printf("Error running %s: %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -34,24 +50,98 @@ func main() {
 
 	// Create repositories and services
 	overtimeRepo := repositories.NewKubernetesOvertimeRepository(k8sClient, cfg.Namespace)
-	excelExporter := exporters.NewExcelReportExporter()
-	emailService := notification.NewSESEmailService(
-		cfg.SenderEmail,
-		cfg.RecipientEmail,
-		cfg.AWSRegion,
-	)
+	reportExporter := exporters.NewDefaultExporterRegistry()
+	excelOptions := exporters.ExcelOptions{Duration: cfg.Duration(exporters.ExcelFormat.Name)}
+	reportExporter.Register(exporters.NewExcelReportExporter(excelOptions))
+	reportExporter.Register(exporters.NewPDFReportExporter(exporters.PDFOptions{
+		Company:  cfg.CompanyName,
+		Employee: cfg.EmployeeName,
+		Approver: cfg.ApproverName,
+		Duration: cfg.Duration(exporters.PDFFormat.Name),
+	}))
+
+	csvOptions := exporters.CSVOptions{
+		Delimiter:        cfg.CSVDelimiter,
+		Language:         cfg.CSVLanguage,
+		Headers:          cfg.CSVHeaders,
+		Columns:          cfg.CSVColumns,
+		DecimalSeparator: cfg.CSVDecimalSeparator,
+		BOM:              cfg.CSVBOM,
+		OmitTotal:        cfg.CSVOmitTotal,
+		Duration:         cfg.Duration(exporters.CSVFormat.Name),
+	}
+	if err := csvOptions.Validate(); err != nil {
+		fmt.Printf("Error in CSV configuration: %v\n", err)
+		os.Exit(1)
+	}
+	reportExporter.Register(exporters.NewCSVReportExporter(csvOptions))
+	reportExporter.Register(exporters.NewODSReportExporter(exporters.ODSOptions{Duration: cfg.Duration(exporters.ODSFormat.Name)}))
+
+	// A customer template replaces the default Excel layout
+	var templates domainrepos.TemplateRepository
+	switch {
+	case cfg.ExcelTemplatePath != "":
+		templates = repositories.NewFileTemplateRepository(cfg.ExcelTemplatePath)
+	case cfg.ExcelTemplateConfigMap != "":
+		templates = repositories.NewKubernetesTemplateRepository(k8sClient, cfg.Namespace, cfg.ExcelTemplateConfigMap, cfg.ExcelTemplateKey)
+	}
+	if templates != nil {
+		reportExporter.Register(exporters.NewExcelTemplateExporter(templates, excelOptions))
+	}
+
+	notificationService := newNotificationService(cfg)
 
 	// Create use case
+	options := []usecases.Option{
+		usecases.WithDailySummary(cfg.NotifyDaily),
+		usecases.WithReportFormats(cfg.ReportFormats...),
+		usecases.WithOutputDir(cfg.OutputDir),
+		usecases.WithRoundingPolicies(cfg.Rounding),
+		usecases.WithOvertimeLimits(cfg.Limits),
+		usecases.WithEntryRules(cfg.EntryRules),
+		usecases.WithJiraBaseURL(cfg.JiraBaseURL),
+	}
+	if cfg.PolicyAlerts {
+		options = append(options, usecases.WithPolicyAlerts(cfg.ManagerEmail))
+	}
+	if cfg.RequireApproval {
+		options = append(options, usecases.WithApprovals(overtimeRepo))
+	}
+	if cfg.JiraBaseURL != "" {
+		jira := repositories.NewJiraTicketRepository(repositories.JiraOptions{
+			BaseURL:     cfg.JiraBaseURL,
+			Email:       cfg.JiraEmail,
+			Token:       cfg.JiraToken,
+			ClientField: cfg.JiraClientField,
+		})
+		options = append(options, usecases.WithTicketEnrichment(repositories.NewCachedTicketRepository(jira)))
+	}
+	if cfg.RemindOnCall {
+		onCallRepo := repositories.NewKubernetesOnCallRepository(k8sClient, cfg.Namespace, cfg.OnCallConfigMap)
+		options = append(options, usecases.WithOnCallReminders(onCallRepo))
+	}
 	overtimeUseCase := usecases.NewOvertimeUseCase(
 		overtimeRepo,
-		excelExporter,
-		emailService,
+		reportExporter,
+		notificationService,
+		options...,
 	)
 
+	// Handle subcommands
+	if len(os.Args) > 1 {
+		if err := runCommand(ctx, cfg, overtimeUseCase, notificationService, os.Args[1], os.Args[2:]); err != nil {
+			fmt.Printf("Error running %s: %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle testing mode or normal operation
 	if cfg.TestingMode {
 		fmt.Println("Running in test mode...")
-		if err := overtimeUseCase.TestMonthlyReport(ctx); err != nil {
+		err := overtimeUseCase.TestMonthlyReport(ctx)
+		printDeliveryResults(notificationService)
+		if err != nil {
 			fmt.Printf("Error in test mode: %v\n", err)
 			os.Exit(1)
 		}
@@ -63,7 +153,13 @@ func main() {
 	now := time.Now().UTC()
 	if now.Day() == 1 {
 		fmt.Println("First day of the month, generating monthly report...")
-		if err := overtimeUseCase.GenerateMonthlyReport(ctx); err != nil {
+		err := overtimeUseCase.GenerateMonthlyReport(ctx)
+		printDeliveryResults(notificationService)
+		if errors.Is(err, usecases.ErrReportAlreadyDelivered) {
+			fmt.Printf("Skipping monthly report: %v\n", err)
+			os.Exit(0)
+		}
+		if err != nil {
 			fmt.Printf("Error generating monthly report: %v\n", err)
 			os.Exit(1)
 		}
@@ -76,4 +172,73 @@ func main() {
 		}
 		fmt.Println("Yesterday's overtime processed successfully!")
 	}
-}
\ No newline at end of file
+}
+
+// newLocalUseCase creates the use case of the local commands. The overtime repository is only
+// created when the command reads or writes entries, from the in-cluster configuration or the
+// kubeconfig of the user; local commands never export or send reports.
+func newLocalUseCase(cfg *config.Config, withRepository bool) (*usecases.OvertimeUseCase, error) {
+	var overtimeRepo domainrepos.OvertimeRepository
+	if withRepository {
+		k8sClient, err := kubernetes.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		overtimeRepo = repositories.NewKubernetesOvertimeRepository(k8sClient, cfg.Namespace)
+	}
+
+	return usecases.NewOvertimeUseCase(overtimeRepo, nil, nil,
+		usecases.WithEntryRules(cfg.EntryRules),
+		usecases.WithJiraBaseURL(cfg.JiraBaseURL),
+	), nil
+}
+
+// newNotificationService creates a notification service fanning out to every configured channel
+func newNotificationService(cfg *config.Config) *notification.MultiNotificationService {
+	var channels []notification.Channel
+	for _, channel := range cfg.NotificationChannels {
+		channels = append(channels, notification.Channel{
+			Name:     channel.Name,
+			Service:  newChannelService(cfg, channel.Name),
+			Required: channel.Required,
+		})
+	}
+	return notification.NewMultiNotificationService(channels...)
+}
+
+// newChannelService creates the notification service for a single channel
+func newChannelService(cfg *config.Config, channel string) domainrepos.NotificationService {
+	messages := notification.WithDurationFormat(cfg.Duration(channel))
+	switch channel {
+	case config.ChannelSlack:
+		return notification.NewSlackWebhookService(cfg.SlackWebhookURL, messages)
+	case config.ChannelTeams:
+		return notification.NewTeamsWebhookService(cfg.TeamsWebhookURL, messages)
+	case config.ChannelWebhook:
+		return notification.NewGenericWebhookService(cfg.WebhookURL, cfg.WebhookHeaders, cfg.WebhookSecret)
+	case config.ChannelArchive:
+		return notification.NewArchiveService(cfg.ArchiveDir)
+	default:
+		return notification.NewSESEmailService(
+			cfg.SenderEmail,
+			cfg.RecipientEmail,
+			cfg.AWSRegion,
+			messages,
+		)
+	}
+}
+
+// printDeliveryResults prints the outcome of the last report delivery for every channel
+func printDeliveryResults(service *notification.MultiNotificationService) {
+	for _, result := range service.ReportResults() {
+		policy := "required"
+		if !result.Required {
+			policy = "optional"
+		}
+		if result.Delivered() {
+			fmt.Printf("  %s (%s): delivered\n", result.Channel, policy)
+		} else {
+			fmt.Printf("  %s (%s): failed: %v\n", result.Channel, policy, result.Err)
+		}
+	}
+}
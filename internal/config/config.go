@@ -3,17 +3,125 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// Supported notification channels
+const (
+	ChannelEmail   = "email"
+	ChannelSlack   = "slack"
+	ChannelTeams   = "teams"
+	ChannelWebhook = "webhook"
+	ChannelArchive = "archive"
 )
 
+// ChannelConfig selects a notification channel and its failure policy
+type ChannelConfig struct {
+	Name string
+
+	// Required channels fail the run when delivery fails, optional ones are only reported
+	Required bool
+}
+
 // Config holds application configuration
 type Config struct {
 	// Kubernetes configuration
 	Namespace string
 
+	// Notification channels used to deliver reports
+	NotificationChannels []ChannelConfig
+
 	// Email configuration
-	SenderEmail   string
+	SenderEmail    string
 	RecipientEmail string
-	AWSRegion     string
+	AWSRegion      string
+
+	// Chat and webhook configuration
+	SlackWebhookURL string
+	TeamsWebhookURL string
+	WebhookURL      string
+	WebhookHeaders  map[string]string
+	WebhookSecret   string
+
+	// Directory where the archive channel keeps report copies
+	ArchiveDir string
+
+	// Export formats attached to the monthly report
+	ReportFormats []string
+
+	// Directory where report files are exported before being sent, the system temporary directory if empty
+	OutputDir string
+
+	// Customer Excel template filled instead of the default workbook, read from a file
+	// or from a ConfigMap binaryData key
+	ExcelTemplatePath      string
+	ExcelTemplateConfigMap string
+	ExcelTemplateKey       string
+
+	// CSV dialect and columns, see exporters.CSVOptions
+	CSVDelimiter        rune
+	CSVLanguage         string
+	CSVColumns          []string
+	CSVHeaders          map[string]string
+	CSVDecimalSeparator string
+	CSVBOM              bool
+	CSVOmitTotal        bool
+
+	// Display of durations in exports and messages. DURATION_FORMAT applies to all of them
+	// and DURATION_FORMATS overrides it by export format or channel, e.g. "pdf=hh_mm;email=decimal"
+	DurationFormat  entities.DurationFormat
+	DurationFormats map[string]entities.DurationFormat
+
+	// Billing increments: ROUNDING_POLICY applies to every ticket and ROUNDING_RULES to the
+	// tickets of a client or project, e.g. "client:Acme=15/up/day;project:OPS=30". Rules may
+	// also select a ticket URL prefix, e.g. "https://acme.atlassian.net/browse/OPS-=30/up/day".
+	// Policies are written as increment[/mode[/scope]], see entities.ParseRoundingPolicy.
+	Rounding entities.RoundingPolicies
+
+	// Overtime limits in minutes per owner (OVERTIME_DAILY_LIMIT, OVERTIME_MONTHLY_LIMIT),
+	// unchecked if unset. POLICY_ALERTS=true alerts MANAGER_EMAIL, or the report recipient.
+	Limits       entities.OvertimeLimits
+	PolicyAlerts bool
+	ManagerEmail string
+
+	// Only approved entries are included in the monthly report (REQUIRE_APPROVAL=true)
+	RequireApproval bool
+
+	// Jira REST API used to add ticket titles, projects and clients to the monthly report,
+	// disabled unless JIRA_BASE_URL is set. JIRA_EMAIL selects basic auth with the token.
+	JiraBaseURL     string
+	JiraEmail       string
+	JiraToken       string
+	JiraClientField string
+
+	// Longest accepted entry in minutes (MAX_ENTRY_MINUTES), longer entries are quarantined
+	EntryRules entities.EntryRules
+
+	// Regular schedule of the team (WORKING_HOURS, WORKING_DAYS, WORKING_TIMEZONE); time
+	// worked outside it is overtime when importing from time trackers
+	WorkingHours entities.WorkingHours
+
+	// Imports from Toggl and Clockify: entries tagged with IMPORT_OVERTIME_TAGS are overtime as
+	// a whole, and IMPORT_TICKET_MAP maps projects and tags to tickets, e.g. "Acme API=https://...".
+	// OVERTIME_OWNER owns the imported entries exported without a user, as in create-cm.sh.
+	ImportOvertimeTags []string
+	ImportTicketMap    map[string]string
+	OvertimeOwner      string
+
+	// Identification printed on PDF timesheets
+	CompanyName  string
+	EmployeeName string
+	ApproverName string
+
+	// Send the daily processing result through the notification channel
+	NotifyDaily bool
+
+	// Remind on-call owners that logged nothing, using the on-call schedule ConfigMap
+	RemindOnCall    bool
+	OnCallConfigMap string
 
 	// Application mode
 	TestingMode bool
@@ -21,36 +129,357 @@ type Config struct {
 
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
+	cfg, err := LoadLocalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the settings required by each selected channel
+	for _, channel := range cfg.NotificationChannels {
+		if err := cfg.validateChannel(channel.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// LoadLocalConfig loads configuration from environment variables without requiring the
+// settings of the notification channels, for the commands run on a workstation that send
+// no notifications
+func LoadLocalConfig() (*Config, error) {
 	// Load namespace, defaulting to "default"
 	namespace := os.Getenv("NAMESPACE")
 	if namespace == "" {
 		namespace = "default"
 	}
 
-	// Load email configuration
-	senderEmail := os.Getenv("SENDER_EMAIL")
-	if senderEmail == "" {
-		return nil, fmt.Errorf("SENDER_EMAIL environment variable is required")
+	// Load notification channels, defaulting to email only.
+	// NOTIFICATION_CHANNEL is still honoured for single channel setups.
+	channelList := os.Getenv("NOTIFICATION_CHANNELS")
+	if channelList == "" {
+		channelList = os.Getenv("NOTIFICATION_CHANNEL")
+	}
+	if channelList == "" {
+		channelList = ChannelEmail
+	}
+
+	channels, err := parseChannels(channelList)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Namespace:              namespace,
+		NotificationChannels:   channels,
+		SenderEmail:            os.Getenv("SENDER_EMAIL"),
+		RecipientEmail:         os.Getenv("RECIPIENT_EMAIL"),
+		AWSRegion:              os.Getenv("AWS_REGION"),
+		SlackWebhookURL:        os.Getenv("SLACK_WEBHOOK_URL"),
+		TeamsWebhookURL:        os.Getenv("TEAMS_WEBHOOK_URL"),
+		WebhookURL:             os.Getenv("WEBHOOK_URL"),
+		WebhookSecret:          os.Getenv("WEBHOOK_SECRET"),
+		ArchiveDir:             os.Getenv("ARCHIVE_DIR"),
+		NotifyDaily:            os.Getenv("NOTIFY_DAILY") == "true",
+		RemindOnCall:           os.Getenv("REMIND_ON_CALL") == "true",
+		OnCallConfigMap:        os.Getenv("ONCALL_CONFIGMAP"),
+		OutputDir:              os.Getenv("OUTPUT_DIR"),
+		ExcelTemplatePath:      os.Getenv("EXCEL_TEMPLATE"),
+		ExcelTemplateConfigMap: os.Getenv("EXCEL_TEMPLATE_CONFIGMAP"),
+		ExcelTemplateKey:       os.Getenv("EXCEL_TEMPLATE_KEY"),
+		CSVLanguage:            strings.ToLower(os.Getenv("CSV_LANGUAGE")),
+		CSVColumns:             parseList(os.Getenv("CSV_COLUMNS")),
+		CSVDecimalSeparator:    os.Getenv("CSV_DECIMAL_SEPARATOR"),
+		CSVBOM:                 os.Getenv("CSV_BOM") == "true",
+		CSVOmitTotal:           os.Getenv("CSV_TOTAL_ROW") == "false",
+		CompanyName:            os.Getenv("COMPANY_NAME"),
+		EmployeeName:           os.Getenv("EMPLOYEE_NAME"),
+		ApproverName:           os.Getenv("APPROVER_NAME"),
+		PolicyAlerts:           os.Getenv("POLICY_ALERTS") == "true",
+		ManagerEmail:           os.Getenv("MANAGER_EMAIL"),
+		RequireApproval:        os.Getenv("REQUIRE_APPROVAL") == "true",
+		JiraBaseURL:            os.Getenv("JIRA_BASE_URL"),
+		JiraEmail:              os.Getenv("JIRA_EMAIL"),
+		JiraToken:              os.Getenv("JIRA_TOKEN"),
+		JiraClientField:        os.Getenv("JIRA_CLIENT_FIELD"),
+		ImportOvertimeTags:     parseList(os.Getenv("IMPORT_OVERTIME_TAGS")),
+		OvertimeOwner:          os.Getenv("OVERTIME_OWNER"),
+		// Check if we're in testing mode
+		TestingMode: os.Getenv("TESTING") == "true",
+	}
+
+	cfg.ReportFormats = parseList(os.Getenv("REPORT_FORMATS"))
+	if len(cfg.ReportFormats) == 0 {
+		cfg.ReportFormats = []string{"xlsx"}
+	}
+
+	if cfg.CompanyName == "" {
+		cfg.CompanyName = "Darede"
 	}
 
-	recipientEmail := os.Getenv("RECIPIENT_EMAIL")
-	if recipientEmail == "" {
-		return nil, fmt.Errorf("RECIPIENT_EMAIL environment variable is required")
+	if cfg.ExcelTemplatePath != "" && cfg.ExcelTemplateConfigMap != "" {
+		return nil, fmt.Errorf("EXCEL_TEMPLATE and EXCEL_TEMPLATE_CONFIGMAP are mutually exclusive")
 	}
 
-	awsRegion := os.Getenv("AWS_REGION")
-	if awsRegion == "" {
-		return nil, fmt.Errorf("AWS_REGION environment variable is required")
+	if cfg.ExcelTemplateKey == "" {
+		cfg.ExcelTemplateKey = "template.xlsx"
 	}
 
-	// Check if we're in testing mode
-	testingMode := os.Getenv("TESTING") == "true"
+	if len(cfg.ImportOvertimeTags) == 0 {
+		cfg.ImportOvertimeTags = []string{"overtime", "hora extra"}
+	}
+
+	if cfg.OnCallConfigMap == "" {
+		cfg.OnCallConfigMap = "overtime-oncall"
+	}
+
+	headers, err := parseHeaders("WEBHOOK_HEADERS", os.Getenv("WEBHOOK_HEADERS"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.WebhookHeaders = headers
+
+	if cfg.ImportTicketMap, err = parseHeaders("IMPORT_TICKET_MAP", os.Getenv("IMPORT_TICKET_MAP")); err != nil {
+		return nil, err
+	}
+
+	csvHeaders, err := parseHeaders("CSV_HEADERS", os.Getenv("CSV_HEADERS"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.CSVHeaders = csvHeaders
+
+	if cfg.CSVDelimiter, err = parseDelimiter(os.Getenv("CSV_DELIMITER")); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadDurationFormats(); err != nil {
+		return nil, err
+	}
 
-	return &Config{
-		Namespace:     namespace,
-		SenderEmail:   senderEmail,
-		RecipientEmail: recipientEmail,
-		AWSRegion:     awsRegion,
-		TestingMode:   testingMode,
-	}, nil
-}
\ No newline at end of file
+	if cfg.Limits.DailyMinutes, err = parseMinutes("OVERTIME_DAILY_LIMIT", os.Getenv("OVERTIME_DAILY_LIMIT")); err != nil {
+		return nil, err
+	}
+	if cfg.Limits.MonthlyMinutes, err = parseMinutes("OVERTIME_MONTHLY_LIMIT", os.Getenv("OVERTIME_MONTHLY_LIMIT")); err != nil {
+		return nil, err
+	}
+
+	if cfg.EntryRules.MaxMinutes, err = parseMinutes("MAX_ENTRY_MINUTES", os.Getenv("MAX_ENTRY_MINUTES")); err != nil {
+		return nil, err
+	}
+
+	if cfg.WorkingHours, err = entities.ParseWorkingHours(os.Getenv("WORKING_HOURS"), os.Getenv("WORKING_DAYS"), os.Getenv("WORKING_TIMEZONE")); err != nil {
+		return nil, fmt.Errorf("invalid working hours: %w", err)
+	}
+
+	if cfg.Rounding, err = parseRounding(os.Getenv("ROUNDING_POLICY"), os.Getenv("ROUNDING_RULES")); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Duration returns the duration format of an export format or notification channel
+func (c *Config) Duration(name string) entities.DurationFormat {
+	if format, ok := c.DurationFormats[name]; ok {
+		return format
+	}
+	return c.DurationFormat
+}
+
+// loadDurationFormats parses the default and per export duration formats, which share
+// the rounding and precision of DURATION_ROUNDING and DURATION_PRECISION
+func (c *Config) loadDurationFormats() error {
+	precision := entities.DefaultDurationPrecision
+	if value := os.Getenv("DURATION_PRECISION"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid DURATION_PRECISION %q: %w", value, err)
+		}
+		precision = parsed
+	}
+	rounding := os.Getenv("DURATION_ROUNDING")
+
+	format, err := entities.ParseDurationFormat(os.Getenv("DURATION_FORMAT"), rounding, precision)
+	if err != nil {
+		return fmt.Errorf("invalid DURATION_FORMAT: %w", err)
+	}
+	c.DurationFormat = format
+
+	overrides, err := parseHeaders("DURATION_FORMATS", os.Getenv("DURATION_FORMATS"))
+	if err != nil {
+		return err
+	}
+	c.DurationFormats = make(map[string]entities.DurationFormat)
+	for name, unit := range overrides {
+		format, err := entities.ParseDurationFormat(unit, rounding, precision)
+		if err != nil {
+			return fmt.Errorf("invalid DURATION_FORMATS entry %s: %w", name, err)
+		}
+		c.DurationFormats[strings.ToLower(name)] = format
+	}
+	return nil
+}
+
+// parseMinutes parses a non-negative number of minutes, zero if the value is empty
+func parseMinutes(variable, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		return 0, fmt.Errorf("invalid %s %q, expected minutes", variable, value)
+	}
+	return minutes, nil
+}
+
+// parseRounding parses the default rounding policy and the per client rules
+func parseRounding(policy, rules string) (entities.RoundingPolicies, error) {
+	var policies entities.RoundingPolicies
+	if policy != "" {
+		parsed, err := entities.ParseRoundingPolicy(policy)
+		if err != nil {
+			return policies, fmt.Errorf("invalid ROUNDING_POLICY: %w", err)
+		}
+		policies.Default = parsed
+	}
+
+	selectors, err := parseHeaders("ROUNDING_RULES", rules)
+	if err != nil {
+		return policies, err
+	}
+	for selector, value := range selectors {
+		parsed, err := entities.ParseRoundingPolicy(value)
+		if err != nil {
+			return policies, fmt.Errorf("invalid ROUNDING_RULES entry %s: %w", selector, err)
+		}
+		rule := entities.RoundingRule{Policy: parsed}
+		kind, name, _ := strings.Cut(selector, ":")
+		switch strings.ToLower(kind) {
+		case "client":
+			rule.Client = strings.TrimSpace(name)
+		case "project":
+			rule.Project = strings.TrimSpace(name)
+		default:
+			rule.Prefix = selector
+		}
+		if rule.Prefix == "" && rule.Client == "" && rule.Project == "" {
+			return policies, fmt.Errorf("invalid ROUNDING_RULES entry %s, expected a client or project name", selector)
+		}
+		policies.Rules = append(policies.Rules, rule)
+	}
+	return policies, nil
+}
+
+// validateChannel checks the settings required by a notification channel
+func (c *Config) validateChannel(channel string) error {
+	switch channel {
+	case ChannelEmail:
+		if c.SenderEmail == "" {
+			return fmt.Errorf("SENDER_EMAIL environment variable is required")
+		}
+		if c.RecipientEmail == "" {
+			return fmt.Errorf("RECIPIENT_EMAIL environment variable is required")
+		}
+		if c.AWSRegion == "" {
+			return fmt.Errorf("AWS_REGION environment variable is required")
+		}
+	case ChannelSlack:
+		if c.SlackWebhookURL == "" {
+			return fmt.Errorf("SLACK_WEBHOOK_URL environment variable is required")
+		}
+	case ChannelTeams:
+		if c.TeamsWebhookURL == "" {
+			return fmt.Errorf("TEAMS_WEBHOOK_URL environment variable is required")
+		}
+	case ChannelWebhook:
+		if c.WebhookURL == "" {
+			return fmt.Errorf("WEBHOOK_URL environment variable is required")
+		}
+	case ChannelArchive:
+		if c.ArchiveDir == "" {
+			return fmt.Errorf("ARCHIVE_DIR environment variable is required")
+		}
+	default:
+		return fmt.Errorf("unsupported notification channel %q", channel)
+	}
+	return nil
+}
+
+// parseChannels parses a comma separated channel list such as "email,slack:optional".
+// Channels are required unless suffixed with ":optional".
+func parseChannels(value string) ([]ChannelConfig, error) {
+	var channels []ChannelConfig
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+
+		name, policy, _ := strings.Cut(item, ":")
+		channel := ChannelConfig{Name: name, Required: true}
+		switch policy {
+		case "", "required":
+		case "optional":
+			channel.Required = false
+		default:
+			return nil, fmt.Errorf("invalid policy %q for notification channel %s", policy, name)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("notification channel %s configured more than once", name)
+		}
+		seen[name] = true
+		channels = append(channels, channel)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("at least one notification channel is required")
+	}
+	return channels, nil
+}
+
+// parseHeaders parses a list of "Name=Value" pairs separated by semicolons
+func parseHeaders(variable, value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, headerValue, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, expected Name=Value", variable, pair)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+	}
+	return headers, nil
+}
+
+// parseDelimiter parses a single character CSV delimiter, accepting "tab" for tabs.
+// It returns zero, the exporter default, when the value is empty.
+func parseDelimiter(value string) (rune, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if strings.EqualFold(value, "tab") || value == "\\t" {
+		return '\t', nil
+	}
+	runes := []rune(value)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return 0, fmt.Errorf("invalid CSV_DELIMITER %q, expected a single character or tab", value)
+	}
+	return runes[0], nil
+}
+
+// parseList parses a comma separated list, lowercasing and dropping empty items
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,248 @@
+package exporters
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// CSVFormat describes CSV files
+var CSVFormat = entities.ExportFormat{
+	Name:      "csv",
+	Extension: ".csv",
+	MIMEType:  "text/csv",
+}
+
+// CSV columns
+const (
+	CSVColumnDate         = "date"
+	CSVColumnOwner        = "owner"
+	CSVColumnTicket       = "ticket"
+	CSVColumnMinutes      = "minutes"
+	CSVColumnHoursDecimal = "hours_decimal"
+	CSVColumnHoursMinutes = "hh_mm"
+	CSVColumnDuration     = "duration" // in the unit of the duration format
+	CSVColumnBillable     = "billable_minutes"
+	CSVColumnDescription  = "description"
+	CSVColumnTicketKey    = "ticket_key" // tracker metadata, empty unless the report was enriched
+	CSVColumnTicketTitle  = "ticket_title"
+	CSVColumnProject      = "project"
+	CSVColumnClient       = "client"
+)
+
+// csvHeaders holds the default header of each column per language
+var csvHeaders = map[string]map[string]string{
+	"pt": {
+		CSVColumnDate:         "DATA",
+		CSVColumnOwner:        "RESPONSÁVEL",
+		CSVColumnTicket:       "TICKET",
+		CSVColumnMinutes:      "MINUTOS",
+		CSVColumnHoursDecimal: "HORAS",
+		CSVColumnHoursMinutes: "HH:MM",
+		CSVColumnDuration:     "DURAÇÃO",
+		CSVColumnBillable:     "MINUTOS FATURÁVEIS",
+		CSVColumnDescription:  "DESCRIÇÃO",
+		CSVColumnTicketKey:    "CHAVE",
+		CSVColumnTicketTitle:  "TÍTULO",
+		CSVColumnProject:      "PROJETO",
+		CSVColumnClient:       "CLIENTE",
+	},
+	"en": {
+		CSVColumnDate:         "DATE",
+		CSVColumnOwner:        "OWNER",
+		CSVColumnTicket:       "TICKET",
+		CSVColumnMinutes:      "MINUTES",
+		CSVColumnHoursDecimal: "HOURS",
+		CSVColumnHoursMinutes: "HH:MM",
+		CSVColumnDuration:     "DURATION",
+		CSVColumnBillable:     "BILLABLE MINUTES",
+		CSVColumnDescription:  "DESCRIPTION",
+		CSVColumnTicketKey:    "KEY",
+		CSVColumnTicketTitle:  "TITLE",
+		CSVColumnProject:      "PROJECT",
+		CSVColumnClient:       "CLIENT",
+	},
+}
+
+// CSVOptions configures the CSV dialect and columns. The zero value writes the
+// original layout: ';' separated TICKET;MINUTOS columns followed by a TOTAL row.
+type CSVOptions struct {
+	Delimiter        rune                    // ';' if zero
+	Language         string                  // header language, "pt" (default) or "en"
+	Headers          map[string]string       // header overrides by column name
+	Columns          []string                // columns in order, ticket and minutes if empty
+	DecimalSeparator string                  // separator of decimal hours, "," if empty
+	Duration         entities.DurationFormat // unit of the duration column, and rounding of decimal hours
+	BOM              bool                    // prefix a UTF-8 byte order mark, so Excel detects the encoding
+	OmitTotal        bool                    // leave out the TOTAL row
+}
+
+// Validate checks the language and columns of the options
+func (o CSVOptions) Validate() error {
+	if o.Language != "" {
+		if _, ok := csvHeaders[o.Language]; !ok {
+			return fmt.Errorf("unsupported CSV language %q, expected pt or en", o.Language)
+		}
+	}
+	for _, column := range o.Columns {
+		if _, ok := csvHeaders["pt"][column]; !ok {
+			return fmt.Errorf("unsupported CSV column %q", column)
+		}
+	}
+	for column := range o.Headers {
+		if _, ok := csvHeaders["pt"][column]; !ok {
+			return fmt.Errorf("unsupported CSV header column %q", column)
+		}
+	}
+	return nil
+}
+
+// CSVReportExporter implements the FormatExporter interface for CSV files
+type CSVReportExporter struct {
+	options CSVOptions
+}
+
+// NewCSVReportExporter creates a new CSV report exporter
+func NewCSVReportExporter(options CSVOptions) *CSVReportExporter {
+	if options.Delimiter == 0 {
+		options.Delimiter = ';' // Semicolon as separator for Excel compatibility
+	}
+	if options.Language == "" {
+		options.Language = "pt"
+	}
+	if len(options.Columns) == 0 {
+		options.Columns = []string{CSVColumnTicket, CSVColumnMinutes}
+	}
+	if options.DecimalSeparator == "" {
+		options.DecimalSeparator = ","
+	}
+	options.Duration = options.Duration.OrDefault()
+	options.Duration.DecimalSeparator = options.DecimalSeparator
+	return &CSVReportExporter{
+		options: options,
+	}
+}
+
+// Format returns the CSV format metadata
+func (e *CSVReportExporter) Format() entities.ExportFormat {
+	return CSVFormat
+}
+
+// Export writes the report as CSV to w
+func (e *CSVReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	if e.options.BOM {
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return fmt.Errorf("error writing CSV byte order mark: %w", err)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = e.options.Delimiter
+
+	// Write header
+	header := make([]string, len(e.options.Columns))
+	for i, column := range e.options.Columns {
+		header[i] = e.header(column)
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("error writing CSV header: %w", err)
+	}
+
+	// Write data rows
+	for _, entry := range report.Entries {
+		row := make([]string, len(e.options.Columns))
+		for i, column := range e.options.Columns {
+			row[i] = e.entryValue(column, entry)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("error writing CSV row: %w", err)
+		}
+	}
+
+	// Write total row
+	if !e.options.OmitTotal {
+		if err := writer.Write(e.totalRow(report)); err != nil {
+			return fmt.Errorf("error writing CSV total row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing CSV file: %w", err)
+	}
+
+	return nil
+}
+
+// header returns the header of a column, honouring overrides
+func (e *CSVReportExporter) header(column string) string {
+	if header, ok := e.options.Headers[column]; ok {
+		return header
+	}
+	return csvHeaders[e.options.Language][column]
+}
+
+// entryValue formats a column of an entry
+func (e *CSVReportExporter) entryValue(column string, entry entities.OvertimeEntry) string {
+	switch column {
+	case CSVColumnDate:
+		return entry.Date.Format("2006-01-02")
+	case CSVColumnOwner:
+		return entry.Owner
+	case CSVColumnTicket:
+		return entry.TicketURL
+	case CSVColumnDescription:
+		return entry.Description
+	case CSVColumnTicketKey:
+		return entry.Ticket.Key
+	case CSVColumnTicketTitle:
+		return entry.Ticket.Title
+	case CSVColumnProject:
+		return entry.Ticket.Project
+	case CSVColumnClient:
+		return entry.Ticket.Client
+	case CSVColumnBillable:
+		return strconv.Itoa(entry.BillableMinutes)
+	default:
+		return e.durationValue(column, entry.Minutes)
+	}
+}
+
+// totalRow returns the TOTAL row: duration columns hold the totals and the first other column the label
+func (e *CSVReportExporter) totalRow(report *entities.OvertimeReport) []string {
+	row := make([]string, len(e.options.Columns))
+	labelled := false
+	for i, column := range e.options.Columns {
+		if column == CSVColumnBillable {
+			row[i] = strconv.Itoa(report.BillableTime)
+		} else if value := e.durationValue(column, report.TotalTime); value != "" {
+			row[i] = value
+		} else if !labelled {
+			row[i] = "TOTAL"
+			labelled = true
+		}
+	}
+	return row
+}
+
+// durationValue formats minutes for the duration columns, and returns "" for the others
+func (e *CSVReportExporter) durationValue(column string, minutes int) string {
+	switch column {
+	case CSVColumnMinutes:
+		return strconv.Itoa(minutes)
+	case CSVColumnHoursDecimal:
+		decimal := e.options.Duration
+		decimal.Unit = entities.DurationDecimalHours
+		return decimal.Format(minutes)
+	case CSVColumnHoursMinutes:
+		return entities.FormatHoursMinutes(minutes)
+	case CSVColumnDuration:
+		return e.options.Duration.Format(minutes)
+	default:
+		return ""
+	}
+}
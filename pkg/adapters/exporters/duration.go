@@ -0,0 +1,76 @@
+package exporters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// durationHeader returns the column header of durations displayed in the given format
+func durationHeader(format entities.DurationFormat) string {
+	switch format.Unit {
+	case entities.DurationDecimalHours:
+		return "HORAS"
+	case entities.DurationHoursMinutes:
+		return "HH:MM"
+	default:
+		return "MINUTOS"
+	}
+}
+
+// hoursFormat returns the display of the summary hours, which are never plain minutes
+func hoursFormat(format entities.DurationFormat) entities.DurationFormat {
+	if format.Unit == entities.DurationMinutes {
+		format.Unit = entities.DurationHoursMinutes
+	}
+	return format
+}
+
+// summaryHoursUnit names the unit of the summary hours rows
+func summaryHoursUnit(format entities.DurationFormat) string {
+	if format.Unit == entities.DurationDecimalHours {
+		return "decimal"
+	}
+	return "HH:MM"
+}
+
+// excelDurationFormula converts the minutes of a cell to the spreadsheet value of the format:
+// rounded decimal hours, or a fraction of a day displayed with a duration number format
+func excelDurationFormula(format entities.DurationFormat, ref string) string {
+	if format.Unit == entities.DurationDecimalHours {
+		round := "ROUND"
+		switch format.Rounding {
+		case entities.RoundUp:
+			round = "ROUNDUP"
+		case entities.RoundDown:
+			round = "ROUNDDOWN"
+		}
+		return fmt.Sprintf("%s(%s/60,%d)", round, ref, format.Precision)
+	}
+	return fmt.Sprintf("%s/1440", ref)
+}
+
+// excelDurationNumFmt returns the Excel number format of excelDurationFormula values
+func excelDurationNumFmt(format entities.DurationFormat) string {
+	if format.Unit == entities.DurationDecimalHours {
+		if format.Precision == 0 {
+			return "0"
+		}
+		return "0." + strings.Repeat("0", format.Precision)
+	}
+	return "[hh]:mm"
+}
+
+// odsDurationFormula is the OpenFormula counterpart of excelDurationFormula
+func odsDurationFormula(format entities.DurationFormat, ref string) string {
+	return strings.ReplaceAll(excelDurationFormula(format, ref), ",", ";")
+}
+
+// odsDurationValue returns the computed value of an odsDurationFormula cell
+func odsDurationValue(format entities.DurationFormat, minutes int) interface{} {
+	if format.Unit == entities.DurationDecimalHours {
+		return format.Hours(minutes)
+	}
+	return odsDuration(minutes)
+}
@@ -2,27 +2,71 @@ package exporters
 
 import (
 	"context"
-	"encoding/csv"
 	"fmt"
-	"os"
-	"strconv"
+	"io"
 	"time"
 
 	"github.com/MateSousa/overtime-script/pkg/domain/entities"
-	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
 	"github.com/xuri/excelize/v2"
 )
 
-// ExcelReportExporter implements the ReportExporter interface for Excel files
-type ExcelReportExporter struct{}
+// ExcelFormat describes Excel workbooks
+var ExcelFormat = entities.ExportFormat{
+	Name:      "xlsx",
+	Extension: ".xlsx",
+	MIMEType:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Workbook sheet names
+const (
+	SummarySheet    = "Resumo"
+	DashboardSheet  = "Dashboard"
+	DailySheet      = "Diário"
+	TicketsSheet    = "Tickets"
+	ProjectsSheet   = "Projetos"
+	EntriesSheet    = "Lançamentos"
+	ViolationsSheet = "Violações" // only when the report has policy violations
+	PendingSheet    = "Pendentes" // only when entries await approval
+)
+
+// ExcelOptions configures the Excel workbook
+type ExcelOptions struct {
+	// Duration selects how hours are displayed. Decimal hours and HH:MM add an hours column
+	// to the entries sheet; the summary hours are HH:MM unless decimal hours are chosen.
+	Duration entities.DurationFormat
+}
+
+// ExcelReportExporter implements the FormatExporter interface for Excel files
+type ExcelReportExporter struct {
+	options ExcelOptions
+}
 
 // NewExcelReportExporter creates a new Excel report exporter
-func NewExcelReportExporter() repositories.ReportExporter {
-	return &ExcelReportExporter{}
+func NewExcelReportExporter(options ExcelOptions) *ExcelReportExporter {
+	options.Duration = options.Duration.OrDefault()
+	return &ExcelReportExporter{
+		options: options,
+	}
+}
+
+// Format returns the Excel format metadata
+func (e *ExcelReportExporter) Format() entities.ExportFormat {
+	return ExcelFormat
+}
+
+// excelStyles holds the cell styles shared by the workbook sheets
+type excelStyles struct {
+	header        int
+	data          int
+	date          int
+	total         int
+	duration      int // numeric hours, displayed with a duration number format
+	durationTotal int
 }
 
-// ExportToExcel exports the report to an Excel file
-func (e *ExcelReportExporter) ExportToExcel(ctx context.Context, report *entities.OvertimeReport) (string, error) {
+// Export writes the report as an Excel workbook to w. Totals are SUM formulas over the
+// entries sheet, so they stay correct when cells are edited.
+func (e *ExcelReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
 	// Create a new Excel file
 	f := excelize.NewFile()
 	defer func() {
@@ -31,145 +75,487 @@ func (e *ExcelReportExporter) ExportToExcel(ctx context.Context, report *entitie
 		}
 	}()
 
-	// Get the default sheet (usually "Sheet1")
-	sheetName := f.GetSheetName(0)
-	
-	// Set column widths
-	f.SetColWidth(sheetName, "A", "A", 50) // Ticket column width
-	f.SetColWidth(sheetName, "B", "B", 15) // Minutes column width
-	
-	// Create header style - Blue background with white text, bold, centered
-	headerStyle, err := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold:   true,
-			Color:  "#FFFFFF",
-			Size:   12,
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"#4472C4"},
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "center",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "#000000", Style: 1},
-			{Type: "top", Color: "#000000", Style: 1},
-			{Type: "right", Color: "#000000", Style: 1},
-			{Type: "bottom", Color: "#000000", Style: 1},
-		},
+	styles, err := newExcelStyles(f, e.hoursFormat())
+	if err != nil {
+		return err
+	}
+
+	// The default sheet becomes the summary, so it opens first
+	if err := f.SetSheetName(f.GetSheetName(0), SummarySheet); err != nil {
+		return fmt.Errorf("error renaming summary sheet: %w", err)
+	}
+	sheets := []string{DashboardSheet, DailySheet, TicketsSheet, ProjectsSheet, EntriesSheet}
+	if len(report.Violations) > 0 {
+		sheets = append(sheets, ViolationsSheet)
+	}
+	if len(report.Pending) > 0 {
+		sheets = append(sheets, PendingSheet)
+	}
+	for _, sheet := range sheets {
+		if _, err := f.NewSheet(sheet); err != nil {
+			return fmt.Errorf("error creating sheet %s: %w", sheet, err)
+		}
+	}
+
+	entries := entriesRange{count: len(report.Entries)}
+	writers := []func(*excelize.File, *entities.OvertimeReport, entriesRange, excelStyles) error{
+		e.writeEntriesSheet,
+		writeDailySheet,
+		writeTicketsSheet,
+		writeProjectsSheet,
+		e.writeSummarySheet,
+		writeDashboardSheet,
+	}
+	if len(report.Violations) > 0 {
+		writers = append(writers, writeViolationsSheet)
+	}
+	if len(report.Pending) > 0 {
+		writers = append(writers, writePendingSheet)
+	}
+	for _, write := range writers {
+		if err := write(f, report, entries, styles); err != nil {
+			return err
+		}
+	}
+
+	// Write the workbook
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("error writing Excel file: %w", err)
+	}
+
+	return nil
+}
+
+// hoursFormat returns the display of hours, which are never plain minutes
+func (e *ExcelReportExporter) hoursFormat() entities.DurationFormat {
+	return hoursFormat(e.options.Duration)
+}
+
+// newExcelStyles creates the workbook cell styles, displaying durations in the given format
+func newExcelStyles(f *excelize.File, hours entities.DurationFormat) (excelStyles, error) {
+	border := []excelize.Border{
+		{Type: "left", Color: "#000000", Style: 1},
+		{Type: "top", Color: "#000000", Style: 1},
+		{Type: "right", Color: "#000000", Style: 1},
+		{Type: "bottom", Color: "#000000", Style: 1},
+	}
+	dateFormat := "dd/mm/yyyy"
+
+	var styles excelStyles
+	var err error
+
+	// Blue background with white text, bold, centered
+	styles.header, err = f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "#FFFFFF", Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    border,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error creating header style: %w", err)
-	}
-	
-	// Create data style - Light borders
-	dataStyle, err := f.NewStyle(&excelize.Style{
-		Border: []excelize.Border{
-			{Type: "left", Color: "#000000", Style: 1},
-			{Type: "top", Color: "#000000", Style: 1},
-			{Type: "right", Color: "#000000", Style: 1},
-			{Type: "bottom", Color: "#000000", Style: 1},
-		},
+		return styles, fmt.Errorf("error creating header style: %w", err)
+	}
+
+	styles.data, err = f.NewStyle(&excelize.Style{Border: border})
+	if err != nil {
+		return styles, fmt.Errorf("error creating data style: %w", err)
+	}
+
+	styles.date, err = f.NewStyle(&excelize.Style{Border: border, CustomNumFmt: &dateFormat})
+	if err != nil {
+		return styles, fmt.Errorf("error creating date style: %w", err)
+	}
+
+	// Bold with gray background
+	styles.total, err = f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		Fill:   excelize.Fill{Type: "pattern", Color: []string{"#D9D9D9"}, Pattern: 1},
+		Border: border,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error creating data style: %w", err)
-	}
-	
-	// Create total row style - Bold with gray background
-	totalStyle, err := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold: true,
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"#D9D9D9"},
-			Pattern: 1,
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "#000000", Style: 1},
-			{Type: "top", Color: "#000000", Style: 1},
-			{Type: "right", Color: "#000000", Style: 1},
-			{Type: "bottom", Color: "#000000", Style: 1},
-		},
+		return styles, fmt.Errorf("error creating total style: %w", err)
+	}
+
+	// Durations stay numbers, so they can be summed and charted
+	durationFormat := excelDurationNumFmt(hours)
+	styles.duration, err = f.NewStyle(&excelize.Style{Border: border, CustomNumFmt: &durationFormat})
+	if err != nil {
+		return styles, fmt.Errorf("error creating duration style: %w", err)
+	}
+
+	styles.durationTotal, err = f.NewStyle(&excelize.Style{
+		Font:         &excelize.Font{Bold: true},
+		Fill:         excelize.Fill{Type: "pattern", Color: []string{"#D9D9D9"}, Pattern: 1},
+		Border:       border,
+		CustomNumFmt: &durationFormat,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error creating total style: %w", err)
-	}
-	
-	// Write headers
-	f.SetCellValue(sheetName, "A1", "TICKET")
-	f.SetCellValue(sheetName, "B1", "MINUTOS")
-	
-	// Apply header style
-	f.SetCellStyle(sheetName, "A1", "B1", headerStyle)
-	
-	// Write data rows
+		return styles, fmt.Errorf("error creating duration total style: %w", err)
+	}
+
+	return styles, nil
+}
+
+// entriesRange locates the entry rows of the entries sheet, used by the formulas of the other sheets
+type entriesRange struct {
+	count int
+}
+
+// lastRow returns the last entry row. An empty row is kept when there are no entries,
+// so ranges stay valid and never include the total row.
+func (r entriesRange) lastRow() int {
+	return max(r.count, 1) + 1
+}
+
+// totalRow returns the row of the entries sheet total
+func (r entriesRange) totalRow() int {
+	return r.lastRow() + 1
+}
+
+// column returns the absolute reference of an entries sheet column, e.g. 'Lançamentos'!$D$2:$D$10
+func (r entriesRange) column(col string) string {
+	return fmt.Sprintf("'%s'!$%s$2:$%s$%d", EntriesSheet, col, col, r.lastRow())
+}
+
+// Entries sheet columns
+const (
+	entryDateCol        = "A"
+	entryTicketCol      = "B"
+	entryDescriptionCol = "C"
+	entryMinutesCol     = "D"
+	entryOwnerCol       = "E"
+	entryMultiplierCol  = "F"
+	entryWeightedCol    = "G"
+	entryBillableCol    = "H"
+	entryHoursCol       = "I" // only written when hours are displayed
+)
+
+// ticketHeaders are the headers of the ticket metadata columns, written after the
+// other entry columns when the report was enriched
+var ticketHeaders = []string{"CHAVE", "TÍTULO", "PROJETO", "CLIENTE"}
+
+// ticketValues returns the ticket metadata of an entry, in the order of ticketHeaders
+func ticketValues(entry entities.OvertimeEntry) []string {
+	return []string{entry.Ticket.Key, entry.Ticket.Title, entry.Ticket.Project, entry.Ticket.Client}
+}
+
+// writeEntriesSheet writes one row per entry, with its date and description
+func (e *ExcelReportExporter) writeEntriesSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := EntriesSheet
+	headers := []string{"DATA", "TICKET", "DESCRIÇÃO", "MINUTOS", "RESPONSÁVEL", "MULTIPLICADOR", "MINUTOS PONDERADOS", "MINUTOS FATURÁVEIS"}
+	widths := []float64{14, 50, 40, 12, 30, 16, 22, 22}
+	withHours := e.options.Duration.Unit != entities.DurationMinutes
+	if withHours {
+		headers = append(headers, durationHeader(e.options.Duration))
+		widths = append(widths, 12)
+	}
+	enriched := report.Enriched()
+	firstTicketCol := len(headers) + 1
+	if enriched {
+		headers = append(headers, ticketHeaders...)
+		widths = append(widths, 14, 50, 24, 24)
+	}
+	if err := writeHeader(f, sheet, headers, widths, styles); err != nil {
+		return err
+	}
+
 	for i, entry := range report.Entries {
-		rowNum := i + 2 // Start from row 2 (after headers)
-		
-		// Set values
-		cellA := fmt.Sprintf("A%d", rowNum)
-		cellB := fmt.Sprintf("B%d", rowNum)
-		f.SetCellValue(sheetName, cellA, entry.TicketURL)
-		f.SetCellValue(sheetName, cellB, entry.Minutes)
-		
-		// Apply data style
-		f.SetCellStyle(sheetName, cellA, cellB, dataStyle)
-	}
-	
-	// Write total row
-	totalRow := len(report.Entries) + 2
-	totalCellA := fmt.Sprintf("A%d", totalRow)
-	totalCellB := fmt.Sprintf("B%d", totalRow)
-	f.SetCellValue(sheetName, totalCellA, "TOTAL")
-	f.SetCellValue(sheetName, totalCellB, report.TotalTime)
-	
-	// Apply total style
-	f.SetCellStyle(sheetName, totalCellA, totalCellB, totalStyle)
-	
-	// Create file with the report period
-	filename := fmt.Sprintf("overtime_%s.xlsx", time.Now().Format("2006-01-02"))
-	if err := f.SaveAs(filename); err != nil {
-		return "", fmt.Errorf("error saving Excel file: %w", err)
-	}
-
-	return filename, nil
-}
-
-// ExportToCSV exports the report to a CSV file (for backward compatibility)
-func (e *ExcelReportExporter) ExportToCSV(ctx context.Context, report *entities.OvertimeReport) (string, error) {
-	// Create CSV file with the current date
-	filename := fmt.Sprintf("overtime_%s.csv", time.Now().Format("2006-01-02"))
-	file, err := os.Create(filename)
-	if err != nil {
-		return "", fmt.Errorf("error creating CSV file: %w", err)
+		row := i + 2
+		multiplier := entry.Multiplier
+		if multiplier == 0 {
+			multiplier = 1
+		}
+
+		f.SetCellValue(sheet, cell(entryDateCol, row), excelDate(entry.Date))
+		f.SetCellValue(sheet, cell(entryTicketCol, row), entry.TicketURL)
+		f.SetCellValue(sheet, cell(entryDescriptionCol, row), entry.Description)
+		f.SetCellValue(sheet, cell(entryMinutesCol, row), entry.Minutes)
+		f.SetCellValue(sheet, cell(entryOwnerCol, row), entry.Owner)
+		f.SetCellValue(sheet, cell(entryMultiplierCol, row), multiplier)
+		f.SetCellFormula(sheet, cell(entryWeightedCol, row), fmt.Sprintf("%s%d*%s%d", entryMinutesCol, row, entryMultiplierCol, row))
+		f.SetCellValue(sheet, cell(entryBillableCol, row), entry.BillableMinutes)
+
+		f.SetCellStyle(sheet, cell(entryDateCol, row), cell(entryDateCol, row), styles.date)
+		f.SetCellStyle(sheet, cell(entryTicketCol, row), cell(entryBillableCol, row), styles.data)
+		if withHours {
+			f.SetCellFormula(sheet, cell(entryHoursCol, row), excelDurationFormula(e.options.Duration, cell(entryMinutesCol, row)))
+			f.SetCellStyle(sheet, cell(entryHoursCol, row), cell(entryHoursCol, row), styles.duration)
+		}
+		if enriched {
+			for j, value := range ticketValues(entry) {
+				col, _ := excelize.ColumnNumberToName(firstTicketCol + j)
+				f.SetCellValue(sheet, cell(col, row), value)
+				f.SetCellStyle(sheet, cell(col, row), cell(col, row), styles.data)
+			}
+		}
+	}
+
+	total := entries.totalRow()
+	f.SetCellValue(sheet, cell(entryDateCol, total), "TOTAL")
+	f.SetCellFormula(sheet, cell(entryMinutesCol, total), fmt.Sprintf("SUM(%s)", entries.column(entryMinutesCol)))
+	f.SetCellFormula(sheet, cell(entryWeightedCol, total), fmt.Sprintf("SUM(%s)", entries.column(entryWeightedCol)))
+	f.SetCellFormula(sheet, cell(entryBillableCol, total), fmt.Sprintf("SUM(%s)", entries.column(entryBillableCol)))
+	f.SetCellStyle(sheet, cell(entryDateCol, total), cell(entryBillableCol, total), styles.total)
+	if withHours {
+		f.SetCellFormula(sheet, cell(entryHoursCol, total), fmt.Sprintf("SUM(%s)", entries.column(entryHoursCol)))
+		f.SetCellStyle(sheet, cell(entryHoursCol, total), cell(entryHoursCol, total), styles.durationTotal)
+	}
+
+	return nil
+}
+
+// writeViolationsSheet lists the days and months above the overtime limits
+func writeViolationsSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := ViolationsSheet
+	headers := []string{"TIPO", "RESPONSÁVEL", "DATA", "MINUTOS", "LIMITE", "EXCESSO"}
+	if err := writeHeader(f, sheet, headers, []float64{12, 30, 14, 12, 12, 12}, styles); err != nil {
+		return err
+	}
+
+	for i, violation := range report.Violations {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), violationKindLabel(violation.Kind))
+		f.SetCellValue(sheet, cell("B", row), violation.Owner)
+		if violation.Kind == entities.ViolationDaily {
+			f.SetCellValue(sheet, cell("C", row), violation.Date)
+		} else {
+			f.SetCellValue(sheet, cell("C", row), violation.Period)
+		}
+		f.SetCellValue(sheet, cell("D", row), violation.Minutes)
+		f.SetCellValue(sheet, cell("E", row), violation.Limit)
+		f.SetCellFormula(sheet, cell("F", row), fmt.Sprintf("D%d-E%d", row, row))
+		f.SetCellStyle(sheet, cell("A", row), cell("F", row), styles.data)
+		f.SetCellStyle(sheet, cell("C", row), cell("C", row), styles.date)
+	}
+	return nil
+}
+
+// writePendingSheet lists the entries awaiting approval, which the other sheets leave out
+func writePendingSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := PendingSheet
+	headers := []string{"DATA", "TICKET", "DESCRIÇÃO", "MINUTOS", "RESPONSÁVEL", "REGISTRO"}
+	if err := writeHeader(f, sheet, headers, []float64{14, 50, 40, 12, 30, 30}, styles); err != nil {
+		return err
+	}
+
+	for i, entry := range report.Pending {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), excelDate(entry.Date))
+		f.SetCellValue(sheet, cell("B", row), entry.TicketURL)
+		f.SetCellValue(sheet, cell("C", row), entry.Description)
+		f.SetCellValue(sheet, cell("D", row), entry.Minutes)
+		f.SetCellValue(sheet, cell("E", row), entry.Owner)
+		f.SetCellValue(sheet, cell("F", row), entry.Source)
+		f.SetCellStyle(sheet, cell("A", row), cell("A", row), styles.date)
+		f.SetCellStyle(sheet, cell("B", row), cell("F", row), styles.data)
+	}
+
+	total := len(report.Pending) + 2
+	f.SetCellValue(sheet, cell("A", total), "TOTAL")
+	f.SetCellFormula(sheet, cell("D", total), fmt.Sprintf("SUM(D2:D%d)", total-1))
+	f.SetCellStyle(sheet, cell("A", total), cell("F", total), styles.total)
+	return nil
+}
+
+// violationKindLabel names a violation kind in the exports
+func violationKindLabel(kind entities.ViolationKind) string {
+	if kind == entities.ViolationDaily {
+		return "Diário"
+	}
+	return "Mensal"
+}
+
+// writeDailySheet writes the minutes logged on each day of the report
+func writeDailySheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := DailySheet
+	if err := writeHeader(f, sheet, []string{"DATA", "LANÇAMENTOS", "MINUTOS"}, []float64{14, 16, 12}, styles); err != nil {
+		return err
+	}
+
+	days := report.ByDay()
+	for i, day := range days {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), excelDate(day.Start))
+		f.SetCellFormula(sheet, cell("B", row), fmt.Sprintf("COUNTIF(%s,A%d)", entries.column(entryDateCol), row))
+		f.SetCellFormula(sheet, cell("C", row), fmt.Sprintf("SUMIF(%s,A%d,%s)", entries.column(entryDateCol), row, entries.column(entryMinutesCol)))
+		f.SetCellStyle(sheet, cell("A", row), cell("A", row), styles.date)
+		f.SetCellStyle(sheet, cell("B", row), cell("C", row), styles.data)
+	}
+
+	writeTotalRow(f, sheet, len(days), []string{"B", "C"}, styles)
+	return nil
+}
+
+// writeTicketsSheet writes the minutes logged on each ticket, most worked first. Spellings of
+// the same ticket URL are grouped together, so the sheet holds values, not formulas.
+func writeTicketsSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := TicketsSheet
+	headers := []string{"TICKET", "LANÇAMENTOS", "MINUTOS", "CHAVE", "PROJETO"}
+	if err := writeHeader(f, sheet, headers, []float64{50, 16, 12, 24, 24}, styles); err != nil {
+		return err
 	}
-	defer file.Close()
 
-	// Create Excel-compatible CSV with semicolons as separators
-	writer := csv.NewWriter(file)
-	writer.Comma = ';' // Use semicolon as separator for Excel compatibility
-	defer writer.Flush()
+	tickets := report.ByTicket()
+	for i, ticket := range tickets {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), ticket.Label)
+		f.SetCellValue(sheet, cell("B", row), ticket.Count())
+		f.SetCellValue(sheet, cell("C", row), ticket.Minutes)
+		ref := entities.ParseTicketRef(ticket.Label)
+		f.SetCellValue(sheet, cell("D", row), ref.Key)
+		f.SetCellValue(sheet, cell("E", row), ref.Project)
+		f.SetCellStyle(sheet, cell("A", row), cell("E", row), styles.data)
+	}
+
+	writeTotalRow(f, sheet, len(tickets), []string{"B", "C"}, styles)
+	return nil
+}
+
+// writeProjectsSheet writes the minutes logged on each project, most worked first. Projects
+// come from the tracker when the report was enriched, so the sheet holds values, not formulas.
+func writeProjectsSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := ProjectsSheet
+	headers := []string{"PROJETO", "LANÇAMENTOS", "MINUTOS", "TICKETS"}
+	if err := writeHeader(f, sheet, headers, []float64{40, 16, 12, 12}, styles); err != nil {
+		return err
+	}
+
+	projects := report.ByProject()
+	for i, project := range projects {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), projectLabel(project))
+		f.SetCellValue(sheet, cell("B", row), project.Count())
+		f.SetCellValue(sheet, cell("C", row), project.Minutes)
+		f.SetCellValue(sheet, cell("D", row), len(project.ByTicket()))
+		f.SetCellStyle(sheet, cell("A", row), cell("D", row), styles.data)
+	}
+
+	writeTotalRow(f, sheet, len(projects), []string{"B", "C"}, styles)
+	return nil
+}
+
+// projectLabel names a project group in the exports
+func projectLabel(project entities.EntryGroup) string {
+	if project.Label == "" {
+		return "Sem projeto"
+	}
+	return project.Label
+}
+
+// writeDashboardSheet charts the daily and per ticket minutes of the daily and tickets sheets
+func writeDashboardSheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := DashboardSheet
+	f.SetColWidth(sheet, "A", "A", 2)
+
+	days := max(len(report.ByDay()), 1) + 1
+	daily := &excelize.Chart{
+		Type:  excelize.Col,
+		Title: []excelize.RichTextRun{{Text: "Horas extras por dia (minutos) - " + report.Period}},
+		Series: []excelize.ChartSeries{{
+			Name:       fmt.Sprintf("'%s'!$C$1", DailySheet),
+			Categories: fmt.Sprintf("'%s'!$A$2:$A$%d", DailySheet, days),
+			Values:     fmt.Sprintf("'%s'!$C$2:$C$%d", DailySheet, days),
+			Fill:       excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		}},
+		Legend:    excelize.ChartLegend{Position: "none"},
+		PlotArea:  excelize.ChartPlotArea{ShowVal: true},
+		Dimension: excelize.ChartDimension{Width: 720, Height: 320},
+	}
+	if err := f.AddChart(sheet, "B2", daily); err != nil {
+		return fmt.Errorf("error adding daily chart: %w", err)
+	}
+
+	// A bar chart keeps long ticket lists readable, unlike a pie
+	tickets := max(len(report.ByTicket()), 1) + 1
+	perTicket := &excelize.Chart{
+		Type:  excelize.Bar,
+		Title: []excelize.RichTextRun{{Text: "Minutos por ticket"}},
+		Series: []excelize.ChartSeries{{
+			Name:       fmt.Sprintf("'%s'!$C$1", TicketsSheet),
+			Categories: fmt.Sprintf("'%s'!$A$2:$A$%d", TicketsSheet, tickets),
+			Values:     fmt.Sprintf("'%s'!$C$2:$C$%d", TicketsSheet, tickets),
+			Fill:       excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		}},
+		Legend:    excelize.ChartLegend{Position: "none"},
+		PlotArea:  excelize.ChartPlotArea{ShowVal: true},
+		Dimension: excelize.ChartDimension{Width: 720, Height: uint(max(320, 40+24*tickets))},
+	}
+	if err := f.AddChart(sheet, "B19", perTicket); err != nil {
+		return fmt.Errorf("error adding ticket chart: %w", err)
+	}
 
-	// Write header
-	if err := writer.Write([]string{"TICKET", "MINUTOS"}); err != nil {
-		return "", fmt.Errorf("error writing CSV header: %w", err)
+	return nil
+}
+
+// writeSummarySheet writes the report totals, computed from the entries sheet
+func (e *ExcelReportExporter) writeSummarySheet(f *excelize.File, report *entities.OvertimeReport, entries entriesRange, styles excelStyles) error {
+	sheet := SummarySheet
+	if err := writeHeader(f, sheet, []string{"RESUMO", report.Period}, []float64{28, 20}, styles); err != nil {
+		return err
 	}
 
-	// Write data rows
-	for _, entry := range report.Entries {
-		if err := writer.Write([]string{entry.TicketURL, strconv.Itoa(entry.Minutes)}); err != nil {
-			return "", fmt.Errorf("error writing CSV row: %w", err)
+	minutesTotal := fmt.Sprintf("'%s'!%s", EntriesSheet, cell(entryMinutesCol, entries.totalRow()))
+	weightedTotal := fmt.Sprintf("'%s'!%s", EntriesSheet, cell(entryWeightedCol, entries.totalRow()))
+	billableTotal := fmt.Sprintf("'%s'!%s", EntriesSheet, cell(entryBillableCol, entries.totalRow()))
+	hours := e.hoursFormat()
+	rows := []struct {
+		label   string
+		formula string
+		style   int
+	}{
+		{"Total de minutos", minutesTotal, styles.data},
+		{"Total de horas (" + summaryHoursUnit(hours) + ")", excelDurationFormula(hours, "B2"), styles.duration},
+		{"Lançamentos", fmt.Sprintf("COUNTA(%s)", entries.column(entryTicketCol)), styles.data},
+		{"Minutos ponderados", weightedTotal, styles.data},
+		{"Horas ponderadas (" + summaryHoursUnit(hours) + ")", excelDurationFormula(hours, "B5"), styles.duration},
+		{"Minutos faturáveis", billableTotal, styles.data},
+		{"Horas faturáveis (" + summaryHoursUnit(hours) + ")", excelDurationFormula(hours, "B7"), styles.duration},
+	}
+
+	for i, r := range rows {
+		row := i + 2
+		f.SetCellValue(sheet, cell("A", row), r.label)
+		f.SetCellFormula(sheet, cell("B", row), r.formula)
+		f.SetCellStyle(sheet, cell("A", row), cell("A", row), styles.data)
+		f.SetCellStyle(sheet, cell("B", row), cell("B", row), r.style)
+	}
+
+	return nil
+}
+
+// writeHeader writes the styled header row and sets the column widths
+func writeHeader(f *excelize.File, sheet string, headers []string, widths []float64, styles excelStyles) error {
+	for i, header := range headers {
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return fmt.Errorf("error naming column %d: %w", i+1, err)
 		}
+		f.SetCellValue(sheet, cell(col, 1), header)
+		f.SetColWidth(sheet, col, col, widths[i])
 	}
 
-	// Write total row
-	if err := writer.Write([]string{"TOTAL", strconv.Itoa(report.TotalTime)}); err != nil {
-		return "", fmt.Errorf("error writing CSV total row: %w", err)
+	last, _ := excelize.ColumnNumberToName(len(headers))
+	f.SetCellStyle(sheet, "A1", cell(last, 1), styles.header)
+	return nil
+}
+
+// writeTotalRow writes a TOTAL row below the given number of data rows, summing the given columns
+func writeTotalRow(f *excelize.File, sheet string, rows int, columns []string, styles excelStyles) {
+	last := max(rows, 1) + 1
+	total := last + 1
+
+	f.SetCellValue(sheet, cell("A", total), "TOTAL")
+	for _, col := range columns {
+		f.SetCellFormula(sheet, cell(col, total), fmt.Sprintf("SUM(%s2:%s%d)", col, col, last))
 	}
+	f.SetCellStyle(sheet, cell("A", total), cell(columns[len(columns)-1], total), styles.total)
+}
+
+// cell returns the reference of a cell, e.g. "B2"
+func cell(col string, row int) string {
+	return fmt.Sprintf("%s%d", col, row)
+}
 
-	return filename, nil
-}
\ No newline at end of file
+// excelDate truncates a time to its day, so dates compare equal in formulas
+func excelDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,285 @@
+package exporters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+	"github.com/xuri/excelize/v2"
+)
+
+// templatePlaceholder matches placeholders such as {{period}} or {{entry.minutes}}
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([a-z_.]+)\s*\}\}`)
+
+// entryPlaceholderPrefix marks the placeholders of the repeating entries row
+const entryPlaceholderPrefix = "entry."
+
+// ExcelTemplateExporter implements the FormatExporter interface by filling the placeholders of a
+// customer provided workbook, listed in reportTemplateValues and entryTemplateValues
+type ExcelTemplateExporter struct {
+	templates repositories.TemplateRepository
+	options   ExcelOptions
+}
+
+// NewExcelTemplateExporter creates a new Excel exporter filling the template of the given repository
+func NewExcelTemplateExporter(templates repositories.TemplateRepository, options ExcelOptions) *ExcelTemplateExporter {
+	options.Duration = options.Duration.OrDefault()
+	return &ExcelTemplateExporter{
+		templates: templates,
+		options:   options,
+	}
+}
+
+// Format returns the Excel format metadata
+func (e *ExcelTemplateExporter) Format() entities.ExportFormat {
+	return ExcelFormat
+}
+
+// Export fills the template with the report and writes the workbook to w
+func (e *ExcelTemplateExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	content, err := e.templates.GetTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("error opening Excel template: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println("Error closing Excel file:", err)
+		}
+	}()
+
+	// Entry rows go first, since repeating them shifts the cells below
+	for _, sheet := range f.GetSheetList() {
+		if err := e.fillEntryRows(f, sheet, report.Entries); err != nil {
+			return err
+		}
+	}
+
+	values := e.reportTemplateValues(report)
+	for _, sheet := range f.GetSheetList() {
+		if err := fillPlaceholders(f, sheet, values); err != nil {
+			return err
+		}
+	}
+	if err := fillDefinedNames(f, values); err != nil {
+		return err
+	}
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("error writing Excel file: %w", err)
+	}
+
+	return nil
+}
+
+// reportTemplateValues returns the values of the report placeholders, e.g. {{total_hours}}.
+// Defined names with the same names are filled too.
+func (e *ExcelTemplateExporter) reportTemplateValues(report *entities.OvertimeReport) map[string]interface{} {
+	return map[string]interface{}{
+		"period":           report.Period,
+		"report_date":      excelDate(report.ReportDate),
+		"total_minutes":    report.TotalTime,
+		"total_hours":      entities.FormatHoursMinutes(report.TotalTime),
+		"total_duration":   templateDuration(e.options.Duration, report.TotalTime),
+		"entry_count":      len(report.Entries),
+		"weighted_minutes": report.TotalWeightedMinutes(),
+		"billable_minutes": report.BillableTime,
+		"violation_count":  len(report.Violations),
+		"pending_count":    len(report.Pending),
+		"pending_minutes":  report.PendingMinutes(),
+	}
+}
+
+// entryTemplateValues returns the values of the entry placeholders, e.g. {{entry.minutes}}. The
+// first row of a sheet holding them is repeated once per entry.
+func (e *ExcelTemplateExporter) entryTemplateValues(entry entities.OvertimeEntry) map[string]interface{} {
+	multiplier := entry.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	return map[string]interface{}{
+		"entry.date":             excelDate(entry.Date),
+		"entry.ticket":           entry.TicketURL,
+		"entry.description":      entry.Description,
+		"entry.minutes":          entry.Minutes,
+		"entry.duration":         templateDuration(e.options.Duration, entry.Minutes),
+		"entry.billable_minutes": entry.BillableMinutes,
+		"entry.owner":            entry.Owner,
+		"entry.multiplier":       multiplier,
+		"entry.ticket_key":       entry.Ticket.Key,
+		"entry.title":            entry.Ticket.Title,
+		"entry.project":          entry.Ticket.Project,
+		"entry.client":           entry.Ticket.Client,
+	}
+}
+
+// templateDuration returns the placeholder value of a duration: numbers for minutes and
+// decimal hours, HH:MM text otherwise
+func templateDuration(format entities.DurationFormat, minutes int) interface{} {
+	switch format.Unit {
+	case entities.DurationDecimalHours:
+		return format.Hours(minutes)
+	case entities.DurationHoursMinutes:
+		return entities.FormatHoursMinutes(minutes)
+	default:
+		return minutes
+	}
+}
+
+// fillEntryRows repeats the entries row of a sheet once per entry and fills it.
+// The row is removed when there are no entries.
+func (e *ExcelTemplateExporter) fillEntryRows(f *excelize.File, sheet string, entries []entities.OvertimeEntry) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return fmt.Errorf("error reading template sheet %s: %w", sheet, err)
+	}
+
+	templateRow := 0
+	for i, row := range rows {
+		if strings.Contains(strings.Join(row, ""), "{{"+entryPlaceholderPrefix) {
+			templateRow = i + 1
+			break
+		}
+	}
+	if templateRow == 0 {
+		return nil
+	}
+	cells := rows[templateRow-1]
+
+	if len(entries) == 0 {
+		if err := f.RemoveRow(sheet, templateRow); err != nil {
+			return fmt.Errorf("error removing template entry row: %w", err)
+		}
+		return nil
+	}
+
+	// Copies keep the styles of the template row
+	for i := 1; i < len(entries); i++ {
+		if err := f.DuplicateRow(sheet, templateRow); err != nil {
+			return fmt.Errorf("error repeating template entry row: %w", err)
+		}
+	}
+
+	for i, entry := range entries {
+		values := e.entryTemplateValues(entry)
+		for col, text := range cells {
+			ref, err := excelize.CoordinatesToCellName(col+1, templateRow+i)
+			if err != nil {
+				return err
+			}
+			if err := fillCell(f, sheet, ref, text, values); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fillPlaceholders replaces the report placeholders of every cell of a sheet
+func fillPlaceholders(f *excelize.File, sheet string, values map[string]interface{}) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return fmt.Errorf("error reading template sheet %s: %w", sheet, err)
+	}
+
+	for r, row := range rows {
+		for c, text := range row {
+			ref, err := excelize.CoordinatesToCellName(c+1, r+1)
+			if err != nil {
+				return err
+			}
+			if err := fillCell(f, sheet, ref, text, values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillDefinedNames writes the report values into the cells referred by defined names of the same name
+func fillDefinedNames(f *excelize.File, values map[string]interface{}) error {
+	for _, name := range f.GetDefinedName() {
+		value, ok := values[name.Name]
+		if !ok {
+			continue
+		}
+
+		sheet, ref, ok := parseCellReference(name.RefersTo)
+		if !ok {
+			continue
+		}
+		if err := f.SetCellValue(sheet, ref, value); err != nil {
+			return fmt.Errorf("error filling defined name %s: %w", name.Name, err)
+		}
+	}
+	return nil
+}
+
+// fillCell replaces the known placeholders of a cell. A cell holding a single placeholder
+// gets the typed value, so numbers and dates stay numeric.
+func fillCell(f *excelize.File, sheet, ref, text string, values map[string]interface{}) error {
+	matches := templatePlaceholder.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if len(matches) == 1 && strings.TrimSpace(text) == matches[0][0] {
+		known, ok := values[matches[0][1]]
+		if !ok {
+			return nil
+		}
+		value = known
+	} else {
+		value = templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+			if known, ok := values[name]; ok {
+				return formatTemplateValue(known)
+			}
+			return placeholder
+		})
+	}
+
+	if err := f.SetCellValue(sheet, ref, value); err != nil {
+		return fmt.Errorf("error filling cell %s!%s: %w", sheet, ref, err)
+	}
+	return nil
+}
+
+// formatTemplateValue formats a value embedded in text
+func formatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("02/01/2006")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseCellReference splits a defined name reference such as 'Sheet 1'!$B$2 into its sheet and
+// cell, using the first cell of ranges
+func parseCellReference(refersTo string) (string, string, bool) {
+	refersTo = strings.TrimPrefix(refersTo, "=")
+	sep := strings.LastIndex(refersTo, "!")
+	if sep < 0 {
+		return "", "", false
+	}
+
+	sheet := strings.Trim(refersTo[:sep], "'")
+	ref, _, _ := strings.Cut(refersTo[sep+1:], ":")
+	return sheet, strings.ReplaceAll(ref, "$", ""), true
+}
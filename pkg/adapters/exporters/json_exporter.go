@@ -0,0 +1,230 @@
+package exporters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// ReportSchemaVersion is the version of the JSON and NDJSON schemas. It is only
+// increased for incompatible changes; new optional fields keep the version.
+const ReportSchemaVersion = 1
+
+// JSONFormat describes JSON report documents
+var JSONFormat = entities.ExportFormat{
+	Name:      "json",
+	Extension: ".json",
+	MIMEType:  "application/json",
+}
+
+// NDJSONFormat describes newline delimited JSON, one entry per line
+var NDJSONFormat = entities.ExportFormat{
+	Name:      "ndjson",
+	Extension: ".ndjson",
+	MIMEType:  "application/x-ndjson",
+}
+
+// jsonHours converts minutes to the decimal hours of the JSON documents. Minutes stay the
+// exact value; hours are a convenience for consumers.
+var jsonHours = entities.DurationFormat{}.OrDefault()
+
+// JSONReport is the document written by the JSON exporter.
+// Dates are written as YYYY-MM-DD and times as RFC 3339.
+type JSONReport struct {
+	SchemaVersion     int             `json:"schema_version"`
+	Period            string          `json:"period"`       // e.g. "Sep-2026"
+	PeriodStart       string          `json:"period_start"` // first day of the period, empty if the period is not a month
+	GeneratedAt       string          `json:"generated_at"`
+	TotalMinutes      int             `json:"total_minutes"`
+	TotalHours        string          `json:"total_hours"`         // total minutes as HH:MM
+	TotalHoursDecimal float64         `json:"total_hours_decimal"` // total minutes as hours, rounded to two places
+	WeightedMinutes   float64         `json:"weighted_minutes"`
+	BillableMinutes   int             `json:"billable_minutes"` // total minutes after the client rounding policies
+	EntryCount        int             `json:"entry_count"`
+	Entries           []JSONEntry     `json:"entries"`
+	Violations        []JSONViolation `json:"violations,omitempty"` // overtime limits exceeded in the period
+	Pending           []JSONEntry     `json:"pending,omitempty"`    // entries awaiting approval, not in the totals
+	Tickets           []JSONGroup     `json:"tickets"`              // entries summed by ticket, most worked first
+	Projects          []JSONGroup     `json:"projects"`             // entries summed by project, most worked first
+	Weeks             []JSONGroup     `json:"weeks"`                // entries summed by ISO week, e.g. 2026-W36
+	Owners            []JSONGroup     `json:"owners"`               // entries summed by owner, most worked first
+}
+
+// JSONGroup is a subtotal of the entries sharing a ticket, project, week or owner
+type JSONGroup struct {
+	Key      string `json:"key"`
+	Label    string `json:"label,omitempty"`
+	Entries  int    `json:"entries"`
+	Minutes  int    `json:"minutes"`
+	Billable int    `json:"billable_minutes"`
+}
+
+// JSONViolation is an overtime limit violation of the JSON export
+type JSONViolation struct {
+	Kind    string `json:"kind"` // daily or monthly
+	Owner   string `json:"owner,omitempty"`
+	Date    string `json:"date,omitempty"` // day of daily violations
+	Minutes int    `json:"minutes"`
+	Limit   int    `json:"limit"`
+	Excess  int    `json:"excess"`
+}
+
+// JSONEntry is a single overtime entry of the JSON and NDJSON exports
+type JSONEntry struct {
+	Date        string  `json:"date"`
+	Ticket      string  `json:"ticket"`
+	Description string  `json:"description,omitempty"`
+	Minutes     int     `json:"minutes"`
+	Hours       float64 `json:"hours_decimal"` // minutes as hours, rounded to two places
+	Billable    int     `json:"billable_minutes"`
+	Owner       string  `json:"owner,omitempty"`
+	Multiplier  float64 `json:"multiplier"`           // 1 when the entry is not weighted
+	Source      string  `json:"source,omitempty"`     // record the entry was logged in
+	TicketKey   string  `json:"ticket_key,omitempty"` // tracker metadata, set when the report was enriched
+	Title       string  `json:"title,omitempty"`
+	Project     string  `json:"project,omitempty"`
+	Client      string  `json:"client,omitempty"`
+}
+
+// NDJSONEntry is a line of the NDJSON export, carrying its period so lines can be consumed on their own
+type NDJSONEntry struct {
+	SchemaVersion int    `json:"schema_version"`
+	Period        string `json:"period"`
+	JSONEntry
+}
+
+// JSONReportExporter implements the FormatExporter interface for JSON documents
+type JSONReportExporter struct{}
+
+// NewJSONReportExporter creates a new JSON report exporter
+func NewJSONReportExporter() *JSONReportExporter {
+	return &JSONReportExporter{}
+}
+
+// Format returns the JSON format metadata
+func (e *JSONReportExporter) Format() entities.ExportFormat {
+	return JSONFormat
+}
+
+// Export writes the report as an indented JSON document to w
+func (e *JSONReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	document := JSONReport{
+		SchemaVersion:     ReportSchemaVersion,
+		Period:            report.Period,
+		GeneratedAt:       report.ReportDate.Format(time.RFC3339),
+		TotalMinutes:      report.TotalTime,
+		TotalHours:        entities.FormatHoursMinutes(report.TotalTime),
+		TotalHoursDecimal: jsonHours.Hours(report.TotalTime),
+		WeightedMinutes:   report.TotalWeightedMinutes(),
+		BillableMinutes:   report.BillableTime,
+		EntryCount:        len(report.Entries),
+		Entries:           make([]JSONEntry, 0, len(report.Entries)),
+		Tickets:           newJSONGroups(report.ByTicket()),
+		Projects:          newJSONGroups(report.ByProject()),
+		Weeks:             newJSONGroups(report.ByWeek()),
+		Owners:            newJSONGroups(report.ByOwner()),
+	}
+	if month, err := entities.ParsePeriod(report.Period); err == nil {
+		document.PeriodStart = month.Format("2006-01-02")
+	}
+	for _, entry := range report.Entries {
+		document.Entries = append(document.Entries, newJSONEntry(entry))
+	}
+	for _, entry := range report.Pending {
+		document.Pending = append(document.Pending, newJSONEntry(entry))
+	}
+	for _, violation := range report.Violations {
+		item := JSONViolation{
+			Kind:    string(violation.Kind),
+			Owner:   violation.Owner,
+			Minutes: violation.Minutes,
+			Limit:   violation.Limit,
+			Excess:  violation.Excess(),
+		}
+		if violation.Kind == entities.ViolationDaily {
+			item.Date = violation.Date.Format("2006-01-02")
+		}
+		document.Violations = append(document.Violations, item)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("error writing JSON report: %w", err)
+	}
+	return nil
+}
+
+// NDJSONReportExporter implements the FormatExporter interface for newline delimited JSON
+type NDJSONReportExporter struct{}
+
+// NewNDJSONReportExporter creates a new NDJSON report exporter
+func NewNDJSONReportExporter() *NDJSONReportExporter {
+	return &NDJSONReportExporter{}
+}
+
+// Format returns the NDJSON format metadata
+func (e *NDJSONReportExporter) Format() entities.ExportFormat {
+	return NDJSONFormat
+}
+
+// Export writes one JSON entry per line to w
+func (e *NDJSONReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range report.Entries {
+		line := NDJSONEntry{
+			SchemaVersion: ReportSchemaVersion,
+			Period:        report.Period,
+			JSONEntry:     newJSONEntry(entry),
+		}
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("error writing NDJSON entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// newJSONEntry converts an overtime entry to its JSON representation
+func newJSONEntry(entry entities.OvertimeEntry) JSONEntry {
+	multiplier := entry.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	return JSONEntry{
+		Date:        entry.Date.Format("2006-01-02"),
+		Ticket:      entry.TicketURL,
+		Description: entry.Description,
+		Minutes:     entry.Minutes,
+		Hours:       jsonHours.Hours(entry.Minutes),
+		Billable:    entry.BillableMinutes,
+		Owner:       entry.Owner,
+		Multiplier:  multiplier,
+		Source:      entry.Source,
+		TicketKey:   entry.Ticket.Key,
+		Title:       entry.Ticket.Title,
+		Project:     entry.Ticket.Project,
+		Client:      entry.Ticket.Client,
+	}
+}
+
+// newJSONGroups converts report groups, keeping their order
+func newJSONGroups(groups []entities.EntryGroup) []JSONGroup {
+	items := make([]JSONGroup, 0, len(groups))
+	for _, group := range groups {
+		item := JSONGroup{
+			Key:      group.Key,
+			Entries:  group.Count(),
+			Minutes:  group.Minutes,
+			Billable: group.BillableMinutes,
+		}
+		if group.Label != group.Key {
+			item.Label = group.Label
+		}
+		items = append(items, item)
+	}
+	return items
+}
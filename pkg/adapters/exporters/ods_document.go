@@ -0,0 +1,220 @@
+package exporters
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// OpenDocument cell styles, matching the Excel header, data, date and total styles
+const (
+	odsHeaderStyle   = "header"
+	odsDataStyle     = "data"
+	odsDateStyle     = "date"
+	odsTotalStyle    = "total"
+	odsDurationStyle = "duration"
+	odsDurationTotal = "duration-total"
+)
+
+// odsCellStyles defines the cell styles of the spreadsheet
+const odsCellStyles = `<number:date-style style:name="N1">` +
+	`<number:day number:style="long"/><number:text>/</number:text>` +
+	`<number:month number:style="long"/><number:text>/</number:text>` +
+	`<number:year number:style="long"/></number:date-style>` +
+	`<number:time-style style:name="N2" number:truncate-on-overflow="false">` +
+	`<number:hours number:style="long"/><number:text>:</number:text>` +
+	`<number:minutes number:style="long"/></number:time-style>` +
+	`<style:style style:name="header" style:family="table-cell">` +
+	`<style:table-cell-properties fo:background-color="#4472c4" fo:border="0.5pt solid #000000" style:vertical-align="middle"/>` +
+	`<style:paragraph-properties fo:text-align="center"/>` +
+	`<style:text-properties fo:font-weight="bold" fo:color="#ffffff" fo:font-size="12pt"/></style:style>` +
+	`<style:style style:name="data" style:family="table-cell">` +
+	`<style:table-cell-properties fo:border="0.5pt solid #000000"/></style:style>` +
+	`<style:style style:name="date" style:family="table-cell" style:data-style-name="N1">` +
+	`<style:table-cell-properties fo:border="0.5pt solid #000000"/></style:style>` +
+	`<style:style style:name="total" style:family="table-cell">` +
+	`<style:table-cell-properties fo:background-color="#d9d9d9" fo:border="0.5pt solid #000000"/>` +
+	`<style:text-properties fo:font-weight="bold"/></style:style>`
+
+// odsDurationStyles defines the duration cell styles, displaying HH:MM or decimal hours with
+// the precision of the format, as excelDurationNumFmt
+func odsDurationStyles(hours entities.DurationFormat) string {
+	dataStyle := "N2"
+	var b strings.Builder
+	if hours.Unit == entities.DurationDecimalHours {
+		dataStyle = "N3"
+		fmt.Fprintf(&b, `<number:number-style style:name="N3"><number:number number:decimal-places="%d" number:min-integer-digits="1"/></number:number-style>`, hours.Precision)
+	}
+	fmt.Fprintf(&b, `<style:style style:name="%s" style:family="table-cell" style:data-style-name="%s">`+
+		`<style:table-cell-properties fo:border="0.5pt solid #000000"/></style:style>`, odsDurationStyle, dataStyle)
+	fmt.Fprintf(&b, `<style:style style:name="%s" style:family="table-cell" style:data-style-name="%s">`+
+		`<style:table-cell-properties fo:background-color="#d9d9d9" fo:border="0.5pt solid #000000"/>`+
+		`<style:text-properties fo:font-weight="bold"/></style:style>`, odsDurationTotal, dataStyle)
+	return b.String()
+}
+
+// odsCell is a spreadsheet cell. Formula cells keep their computed value, so the
+// document shows correct totals even before it is recalculated.
+type odsCell struct {
+	Value   interface{} // string, int, float64, time.Time or odsDuration
+	Formula string      // OpenFormula expression, e.g. SUM([.D2:.D4])
+	Style   string
+}
+
+// odsDuration is a cell value of minutes, stored as a time so it stays numeric
+type odsDuration int
+
+// odsSheet is a spreadsheet table
+type odsSheet struct {
+	Name   string
+	Widths []float64 // column widths in characters, as in the Excel layout
+	Rows   [][]odsCell
+}
+
+// writeODS writes the sheets as an OpenDocument spreadsheet, displaying durations as hours
+func writeODS(w io.Writer, sheets []odsSheet, hours entities.DurationFormat) error {
+	archive := zip.NewWriter(w)
+
+	// The mimetype must be the first entry and stored uncompressed
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, ODSFormat.MIMEType); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/manifest.xml", odsManifest()},
+		{"content.xml", odsContent(sheets, hours)},
+	}
+	for _, file := range files {
+		entry, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, file.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// odsManifest lists the files of the document
+func odsManifest() string {
+	return xml.Header +
+		`<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+		`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + ODSFormat.MIMEType + `"/>` +
+		`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+		`</manifest:manifest>`
+}
+
+// odsContent serializes the sheets and their styles
+func odsContent(sheets []odsSheet, hours entities.DurationFormat) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<office:document-content` +
+		` xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+		` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"` +
+		` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+		` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+		` xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"` +
+		` xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2"` +
+		` office:version="1.2">`)
+
+	// One column style per distinct width
+	b.WriteString(`<office:automatic-styles>`)
+	columnStyles := make(map[float64]string)
+	for _, sheet := range sheets {
+		for _, width := range sheet.Widths {
+			if _, ok := columnStyles[width]; ok {
+				continue
+			}
+			name := fmt.Sprintf("co%d", len(columnStyles)+1)
+			columnStyles[width] = name
+			fmt.Fprintf(&b, `<style:style style:name="%s" style:family="table-column">`+
+				`<style:table-column-properties style:column-width="%.2fcm"/></style:style>`, name, width*0.2)
+		}
+	}
+	b.WriteString(odsCellStyles)
+	b.WriteString(odsDurationStyles(hours))
+	b.WriteString(`</office:automatic-styles><office:body><office:spreadsheet>`)
+	// COUNTIF and SUMIF criteria must match literally, and not as the regular expressions
+	// assumed by the OpenDocument defaults
+	b.WriteString(`<table:calculation-settings table:use-regular-expressions="false"` +
+		` table:use-wildcards="false" table:search-criteria-must-apply-to-whole-cell="true"/>`)
+
+	for _, sheet := range sheets {
+		fmt.Fprintf(&b, `<table:table table:name="%s">`, odsEscape(sheet.Name))
+		for _, width := range sheet.Widths {
+			fmt.Fprintf(&b, `<table:table-column table:style-name="%s"/>`, columnStyles[width])
+		}
+		for _, row := range sheet.Rows {
+			b.WriteString(`<table:table-row>`)
+			for _, cell := range row {
+				writeODSCell(&b, cell)
+			}
+			b.WriteString(`</table:table-row>`)
+		}
+		b.WriteString(`</table:table>`)
+	}
+
+	b.WriteString(`</office:spreadsheet></office:body></office:document-content>`)
+	return b.String()
+}
+
+// writeODSCell serializes a cell with its typed value
+func writeODSCell(b *strings.Builder, cell odsCell) {
+	b.WriteString(`<table:table-cell`)
+	if cell.Style != "" {
+		fmt.Fprintf(b, ` table:style-name="%s"`, cell.Style)
+	}
+	if cell.Formula != "" {
+		fmt.Fprintf(b, ` table:formula="of:=%s"`, odsEscape(cell.Formula))
+	}
+
+	var display string
+	switch v := cell.Value.(type) {
+	case string:
+		display = v
+		b.WriteString(` office:value-type="string"`)
+		if cell.Formula != "" {
+			fmt.Fprintf(b, ` office:string-value="%s"`, odsEscape(v))
+		}
+	case int:
+		display = strconv.Itoa(v)
+		fmt.Fprintf(b, ` office:value-type="float" office:value="%d"`, v)
+	case float64:
+		display = strconv.FormatFloat(v, 'f', -1, 64)
+		fmt.Fprintf(b, ` office:value-type="float" office:value="%s"`, display)
+	case odsDuration:
+		display = entities.FormatHoursMinutes(int(v))
+		fmt.Fprintf(b, ` office:value-type="time" office:time-value="PT%dH%02dM00S"`, v/60, v%60)
+	case time.Time:
+		display = v.Format("02/01/2006")
+		fmt.Fprintf(b, ` office:value-type="date" office:date-value="%s"`, v.Format("2006-01-02"))
+	default:
+		b.WriteString(`/>`)
+		return
+	}
+
+	fmt.Fprintf(b, `><text:p>%s</text:p></table:table-cell>`, odsEscape(display))
+}
+
+// odsEscape escapes text for XML content and attributes
+func odsEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
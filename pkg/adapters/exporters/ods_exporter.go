@@ -0,0 +1,350 @@
+package exporters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// ODSFormat describes OpenDocument spreadsheets
+var ODSFormat = entities.ExportFormat{
+	Name:      "ods",
+	Extension: ".ods",
+	MIMEType:  "application/vnd.oasis.opendocument.spreadsheet",
+}
+
+// ODSOptions configures the OpenDocument spreadsheet
+type ODSOptions struct {
+	// Duration selects how hours are displayed, as in ExcelOptions
+	Duration entities.DurationFormat
+}
+
+// ODSReportExporter implements the FormatExporter interface for OpenDocument spreadsheets.
+// It writes the sheets, styles and formulas of the Excel workbook; the dashboard charts
+// are Excel only.
+type ODSReportExporter struct {
+	options ODSOptions
+}
+
+// NewODSReportExporter creates a new OpenDocument report exporter
+func NewODSReportExporter(options ODSOptions) *ODSReportExporter {
+	options.Duration = options.Duration.OrDefault()
+	return &ODSReportExporter{
+		options: options,
+	}
+}
+
+// Format returns the OpenDocument format metadata
+func (e *ODSReportExporter) Format() entities.ExportFormat {
+	return ODSFormat
+}
+
+// Export writes the report as an OpenDocument spreadsheet to w
+func (e *ODSReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	entries := entriesRange{count: len(report.Entries)}
+	sheets := []odsSheet{
+		e.odsSummarySheet(report, entries),
+		odsDailySheet(report, entries),
+		odsTicketsSheet(report),
+		odsProjectsSheet(report),
+		e.odsEntriesSheet(report, entries),
+	}
+	if len(report.Violations) > 0 {
+		sheets = append(sheets, odsViolationsSheet(report))
+	}
+	if len(report.Pending) > 0 {
+		sheets = append(sheets, odsPendingSheet(report))
+	}
+
+	if err := writeODS(w, sheets, hoursFormat(e.options.Duration)); err != nil {
+		return fmt.Errorf("error writing ODS file: %w", err)
+	}
+	return nil
+}
+
+// odsEntriesSheet lists every entry, as the Excel entries sheet
+func (e *ODSReportExporter) odsEntriesSheet(report *entities.OvertimeReport, entries entriesRange) odsSheet {
+	headers := []string{"DATA", "TICKET", "DESCRIÇÃO", "MINUTOS", "RESPONSÁVEL", "MULTIPLICADOR", "MINUTOS PONDERADOS", "MINUTOS FATURÁVEIS"}
+	widths := []float64{14, 50, 40, 12, 30, 16, 22, 22}
+	duration := e.options.Duration
+	withHours := duration.Unit != entities.DurationMinutes
+	if withHours {
+		headers = append(headers, durationHeader(duration))
+		widths = append(widths, 12)
+	}
+	enriched := report.Enriched()
+	if enriched {
+		headers = append(headers, ticketHeaders...)
+		widths = append(widths, 14, 50, 24, 24)
+	}
+	sheet := odsSheet{
+		Name:   EntriesSheet,
+		Widths: widths,
+		Rows:   [][]odsCell{odsHeaderRow(headers...)},
+	}
+
+	for i, entry := range report.Entries {
+		row := i + 2
+		multiplier := entry.Multiplier
+		if multiplier == 0 {
+			multiplier = 1
+		}
+		cells := []odsCell{
+			{Value: excelDate(entry.Date), Style: odsDateStyle},
+			{Value: entry.TicketURL, Style: odsDataStyle},
+			{Value: entry.Description, Style: odsDataStyle},
+			{Value: entry.Minutes, Style: odsDataStyle},
+			{Value: entry.Owner, Style: odsDataStyle},
+			{Value: multiplier, Style: odsDataStyle},
+			{Value: entry.WeightedMinutes(), Formula: fmt.Sprintf("[.D%d]*[.F%d]", row, row), Style: odsDataStyle},
+			{Value: entry.BillableMinutes, Style: odsDataStyle},
+		}
+		if withHours {
+			cells = append(cells, odsCell{
+				Value:   odsDurationValue(duration, entry.Minutes),
+				Formula: odsDurationFormula(duration, fmt.Sprintf("[.D%d]", row)),
+				Style:   odsDurationStyle,
+			})
+		}
+		if enriched {
+			for _, value := range ticketValues(entry) {
+				cells = append(cells, odsCell{Value: value, Style: odsDataStyle})
+			}
+		}
+		sheet.Rows = append(sheet.Rows, cells)
+	}
+	if entries.count == 0 {
+		sheet.Rows = append(sheet.Rows, []odsCell{{}})
+	}
+
+	total := []odsCell{
+		{Value: "TOTAL", Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Value: report.TotalTime, Formula: fmt.Sprintf("SUM([.D2:.D%d])", entries.lastRow()), Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Value: report.TotalWeightedMinutes(), Formula: fmt.Sprintf("SUM([.G2:.G%d])", entries.lastRow()), Style: odsTotalStyle},
+		{Value: report.BillableTime, Formula: fmt.Sprintf("SUM([.H2:.H%d])", entries.lastRow()), Style: odsTotalStyle},
+	}
+	if withHours {
+		total = append(total, odsCell{
+			Value:   odsHoursTotal(duration, report),
+			Formula: fmt.Sprintf("SUM([.I2:.I%d])", entries.lastRow()),
+			Style:   odsDurationTotal,
+		})
+	}
+	sheet.Rows = append(sheet.Rows, total)
+	return sheet
+}
+
+// odsHoursTotal returns the sum of the hours column of the entries sheet. Decimal hours
+// are rounded per entry, so their sum can differ from the rounded total.
+func odsHoursTotal(duration entities.DurationFormat, report *entities.OvertimeReport) interface{} {
+	if duration.Unit != entities.DurationDecimalHours {
+		return odsDuration(report.TotalTime)
+	}
+	hours := 0.0
+	for _, entry := range report.Entries {
+		hours += duration.Hours(entry.Minutes)
+	}
+	scale := math.Pow(10, float64(duration.Precision))
+	return math.Round(hours*scale) / scale
+}
+
+// odsViolationsSheet lists the policy violations, as the Excel violations sheet
+func odsViolationsSheet(report *entities.OvertimeReport) odsSheet {
+	sheet := odsSheet{
+		Name:   ViolationsSheet,
+		Widths: []float64{12, 30, 14, 12, 12, 12},
+		Rows:   [][]odsCell{odsHeaderRow("TIPO", "RESPONSÁVEL", "DATA", "MINUTOS", "LIMITE", "EXCESSO")},
+	}
+
+	for i, violation := range report.Violations {
+		row := i + 2
+		when := odsCell{Value: violation.Period, Style: odsDataStyle}
+		if violation.Kind == entities.ViolationDaily {
+			when = odsCell{Value: violation.Date, Style: odsDateStyle}
+		}
+		sheet.Rows = append(sheet.Rows, []odsCell{
+			{Value: violationKindLabel(violation.Kind), Style: odsDataStyle},
+			{Value: violation.Owner, Style: odsDataStyle},
+			when,
+			{Value: violation.Minutes, Style: odsDataStyle},
+			{Value: violation.Limit, Style: odsDataStyle},
+			{Value: violation.Excess(), Formula: fmt.Sprintf("[.D%d]-[.E%d]", row, row), Style: odsDataStyle},
+		})
+	}
+	return sheet
+}
+
+// odsPendingSheet lists the entries awaiting approval, as the Excel pending sheet
+func odsPendingSheet(report *entities.OvertimeReport) odsSheet {
+	sheet := odsSheet{
+		Name:   PendingSheet,
+		Widths: []float64{14, 50, 40, 12, 30, 30},
+		Rows:   [][]odsCell{odsHeaderRow("DATA", "TICKET", "DESCRIÇÃO", "MINUTOS", "RESPONSÁVEL", "REGISTRO")},
+	}
+
+	for _, entry := range report.Pending {
+		sheet.Rows = append(sheet.Rows, []odsCell{
+			{Value: excelDate(entry.Date), Style: odsDateStyle},
+			{Value: entry.TicketURL, Style: odsDataStyle},
+			{Value: entry.Description, Style: odsDataStyle},
+			{Value: entry.Minutes, Style: odsDataStyle},
+			{Value: entry.Owner, Style: odsDataStyle},
+			{Value: entry.Source, Style: odsDataStyle},
+		})
+	}
+
+	sheet.Rows = append(sheet.Rows, []odsCell{
+		{Value: "TOTAL", Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Value: report.PendingMinutes(), Formula: fmt.Sprintf("SUM([.D2:.D%d])", len(report.Pending)+1), Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+		{Style: odsTotalStyle},
+	})
+	return sheet
+}
+
+// odsDailySheet sums the minutes of each day, as the Excel daily sheet
+func odsDailySheet(report *entities.OvertimeReport, entries entriesRange) odsSheet {
+	sheet := odsSheet{
+		Name:   DailySheet,
+		Widths: []float64{14, 16, 12},
+		Rows:   [][]odsCell{odsHeaderRow("DATA", "LANÇAMENTOS", "MINUTOS")},
+	}
+
+	days := report.ByDay()
+	for i, day := range days {
+		row := i + 2
+		sheet.Rows = append(sheet.Rows, []odsCell{
+			{Value: excelDate(day.Start), Style: odsDateStyle},
+			{Value: day.Count(), Formula: fmt.Sprintf("COUNTIF(%s;[.A%d])", odsEntriesColumn(entries, entryDateCol), row), Style: odsDataStyle},
+			{Value: day.Minutes, Formula: fmt.Sprintf("SUMIF(%s;[.A%d];%s)", odsEntriesColumn(entries, entryDateCol), row, odsEntriesColumn(entries, entryMinutesCol)), Style: odsDataStyle},
+		})
+	}
+
+	sheet.Rows = append(sheet.Rows, odsTotalRows(len(days), len(report.Entries), report.TotalTime)...)
+	return sheet
+}
+
+// odsTicketsSheet sums the minutes of each ticket, as the Excel tickets sheet
+func odsTicketsSheet(report *entities.OvertimeReport) odsSheet {
+	sheet := odsSheet{
+		Name:   TicketsSheet,
+		Widths: []float64{50, 16, 12, 24, 24},
+		Rows:   [][]odsCell{odsHeaderRow("TICKET", "LANÇAMENTOS", "MINUTOS", "CHAVE", "PROJETO")},
+	}
+
+	tickets := report.ByTicket()
+	for _, ticket := range tickets {
+		ref := entities.ParseTicketRef(ticket.Label)
+		sheet.Rows = append(sheet.Rows, []odsCell{
+			{Value: ticket.Label, Style: odsDataStyle},
+			{Value: ticket.Count(), Style: odsDataStyle},
+			{Value: ticket.Minutes, Style: odsDataStyle},
+			{Value: ref.Key, Style: odsDataStyle},
+			{Value: ref.Project, Style: odsDataStyle},
+		})
+	}
+
+	sheet.Rows = append(sheet.Rows, odsTotalRows(len(tickets), len(report.Entries), report.TotalTime)...)
+	return sheet
+}
+
+// odsProjectsSheet sums the minutes of each project, as the Excel projects sheet
+func odsProjectsSheet(report *entities.OvertimeReport) odsSheet {
+	sheet := odsSheet{
+		Name:   ProjectsSheet,
+		Widths: []float64{40, 16, 12, 12},
+		Rows:   [][]odsCell{odsHeaderRow("PROJETO", "LANÇAMENTOS", "MINUTOS", "TICKETS")},
+	}
+
+	projects := report.ByProject()
+	for _, project := range projects {
+		sheet.Rows = append(sheet.Rows, []odsCell{
+			{Value: projectLabel(project), Style: odsDataStyle},
+			{Value: project.Count(), Style: odsDataStyle},
+			{Value: project.Minutes, Style: odsDataStyle},
+			{Value: len(project.ByTicket()), Style: odsDataStyle},
+		})
+	}
+
+	sheet.Rows = append(sheet.Rows, odsTotalRows(len(projects), len(report.Entries), report.TotalTime)...)
+	return sheet
+}
+
+// odsSummarySheet writes the report totals, computed from the entries sheet
+func (e *ODSReportExporter) odsSummarySheet(report *entities.OvertimeReport, entries entriesRange) odsSheet {
+	total := fmt.Sprintf("[$'%s'.D%d]", EntriesSheet, entries.totalRow())
+	weighted := fmt.Sprintf("[$'%s'.G%d]", EntriesSheet, entries.totalRow())
+	billable := fmt.Sprintf("[$'%s'.H%d]", EntriesSheet, entries.totalRow())
+	weightedMinutes := report.TotalWeightedMinutes()
+	hours := hoursFormat(e.options.Duration)
+	unit := " (" + summaryHoursUnit(hours) + ")"
+
+	return odsSheet{
+		Name:   SummarySheet,
+		Widths: []float64{28, 20},
+		Rows: [][]odsCell{
+			odsHeaderRow("RESUMO", report.Period),
+			odsSummaryRow("Total de minutos", report.TotalTime, total),
+			odsDurationRow(hours, "Total de horas"+unit, report.TotalTime, "[.B2]"),
+			odsSummaryRow("Lançamentos", len(report.Entries), fmt.Sprintf("COUNTA(%s)", odsEntriesColumn(entries, entryTicketCol))),
+			odsSummaryRow("Minutos ponderados", weightedMinutes, weighted),
+			odsDurationRow(hours, "Horas ponderadas"+unit, int(math.Round(weightedMinutes)), "[.B5]"),
+			odsSummaryRow("Minutos faturáveis", report.BillableTime, billable),
+			odsDurationRow(hours, "Horas faturáveis"+unit, report.BillableTime, "[.B7]"),
+		},
+	}
+}
+
+// odsHeaderRow returns a row of header cells
+func odsHeaderRow(titles ...string) []odsCell {
+	row := make([]odsCell, len(titles))
+	for i, title := range titles {
+		row[i] = odsCell{Value: title, Style: odsHeaderStyle}
+	}
+	return row
+}
+
+// odsSummaryRow returns a labelled formula row of the summary sheet
+func odsSummaryRow(label string, value interface{}, formula string) []odsCell {
+	return []odsCell{
+		{Value: label, Style: odsDataStyle},
+		{Value: value, Formula: formula, Style: odsDataStyle},
+	}
+}
+
+// odsDurationRow returns a labelled row of the summary sheet converting the minutes of ref to hours
+func odsDurationRow(hours entities.DurationFormat, label string, minutes int, ref string) []odsCell {
+	return []odsCell{
+		{Value: label, Style: odsDataStyle},
+		{Value: odsDurationValue(hours, minutes), Formula: odsDurationFormula(hours, ref), Style: odsDurationStyle},
+	}
+}
+
+// odsTotalRows returns the TOTAL row below the given number of data rows, preceded by an
+// empty row when there is no data, as in the Excel layout
+func odsTotalRows(rows, count, minutes int) [][]odsCell {
+	last := max(rows, 1) + 1
+	var result [][]odsCell
+	if rows == 0 {
+		result = append(result, []odsCell{{}})
+	}
+	return append(result, []odsCell{
+		{Value: "TOTAL", Style: odsTotalStyle},
+		{Value: count, Formula: fmt.Sprintf("SUM([.B2:.B%d])", last), Style: odsTotalStyle},
+		{Value: minutes, Formula: fmt.Sprintf("SUM([.C2:.C%d])", last), Style: odsTotalStyle},
+	})
+}
+
+// odsEntriesColumn returns the absolute reference of an entries sheet column
+func odsEntriesColumn(entries entriesRange, col string) string {
+	return fmt.Sprintf("[$'%s'.$%s$2:.$%s$%d]", EntriesSheet, col, col, entries.lastRow())
+}
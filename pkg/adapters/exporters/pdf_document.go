@@ -0,0 +1,173 @@
+package exporters
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+)
+
+// pdfColor is an RGB color with components between 0 and 1
+type pdfColor struct {
+	R, G, B float64
+}
+
+var (
+	pdfBlack     = pdfColor{0, 0, 0}
+	pdfWhite     = pdfColor{1, 1, 1}
+	pdfHeaderRGB = pdfColor{0x44 / 255.0, 0x72 / 255.0, 0xC4 / 255.0} // #4472C4, as in the Excel header
+	pdfTotalRGB  = pdfColor{0xD9 / 255.0, 0xD9 / 255.0, 0xD9 / 255.0} // #D9D9D9, as in the Excel total row
+)
+
+// helveticaWidths holds the Helvetica glyph widths of the printable ASCII characters, in 1/1000 em
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' to '/'
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // '0' to '?'
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // '@' to 'O'
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // 'P' to '_'
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // '`' to 'o'
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // 'p' to '~'
+}
+
+// pdfDocument is a minimal PDF writer drawing text, lines and rectangles with the
+// standard Helvetica fonts, so no font files or cgo are needed
+type pdfDocument struct {
+	pages []*bytes.Buffer
+}
+
+// addPage starts a new page; drawing operations apply to the last page
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// page returns the content stream of the current page
+func (d *pdfDocument) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// text draws a single line of text with its baseline starting at x, y
+func (d *pdfDocument) text(x, y, size float64, bold bool, color pdfColor, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT %.3f %.3f %.3f rg /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		color.R, color.G, color.B, font, size, x, y, pdfEscape(s))
+}
+
+// textRight draws text right aligned at x
+func (d *pdfDocument) textRight(x, y, size float64, bold bool, color pdfColor, s string) {
+	d.text(x-textWidth(s, size), y, size, bold, color, s)
+}
+
+// line draws a straight line
+func (d *pdfDocument) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page(), "0 0 0 RG %.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// rect draws a rectangle filled with color and outlined in black
+func (d *pdfDocument) rect(x, y, w, h float64, fill pdfColor) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f rg 0 0 0 RG 0.5 w %.2f %.2f %.2f %.2f re B\n",
+		fill.R, fill.G, fill.B, x, y, w, h)
+}
+
+// write serializes the document
+func (d *pdfDocument) write(w io.Writer) error {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 to 4 are the catalog, the page tree and the two fonts;
+	// each page then takes two objects, the page and its content stream
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	// Cross-reference table
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// pdfEscape encodes text as WinAnsi, which covers Portuguese accents, and escapes
+// the string delimiters. Characters outside Latin-1 are replaced with '?'.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '•':
+			b.WriteByte(0x95)
+		case r >= 0x20 && r <= 0x7E:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// textWidth estimates the width of text in points using the Helvetica metrics
+func textWidth(s string, size float64) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 0x20 && r <= 0x7E {
+			units += helveticaWidths[r-0x20]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// fitText truncates text with an ellipsis so it fits the given width
+func fitText(s string, size, width float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,258 @@
+package exporters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// PDFFormat describes PDF documents
+var PDFFormat = entities.ExportFormat{
+	Name:      "pdf",
+	Extension: ".pdf",
+	MIMEType:  "application/pdf",
+}
+
+// PDFOptions holds the identification printed on PDF timesheets
+type PDFOptions struct {
+	Company  string
+	Employee string
+	Approver string
+	Duration entities.DurationFormat // unit of the duration column, minutes if zero
+}
+
+// PDFReportExporter implements the FormatExporter interface for signed PDF timesheets
+type PDFReportExporter struct {
+	options PDFOptions
+}
+
+// NewPDFReportExporter creates a new PDF report exporter
+func NewPDFReportExporter(options PDFOptions) *PDFReportExporter {
+	options.Duration = options.Duration.OrDefault()
+	return &PDFReportExporter{
+		options: options,
+	}
+}
+
+// Format returns the PDF format metadata
+func (e *PDFReportExporter) Format() entities.ExportFormat {
+	return PDFFormat
+}
+
+// Page layout, in points
+const (
+	pdfMargin    = 50.0
+	pdfRowHeight = 18.0
+	pdfFontSize  = 10.0
+	pdfDateX     = pdfMargin
+	pdfTicketX   = pdfMargin + 75
+	pdfMinutesX  = pdfPageWidth - pdfMargin
+	pdfTableEnd  = pdfPageWidth - pdfMargin
+	pdfFooterY   = 150.0 // space kept free for the signature block
+)
+
+// Export writes the report as a PDF timesheet to w
+func (e *PDFReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error {
+	doc := &pdfDocument{}
+	doc.addPage()
+
+	y := e.drawHeader(doc, report)
+	y = e.drawTableHeader(doc, y)
+
+	// Write data rows, continuing on new pages when needed
+	for _, entry := range report.Entries {
+		y = e.nextRow(doc, y)
+		doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfWhite)
+		doc.text(pdfDateX+5, y+5, pdfFontSize, false, pdfBlack, entry.Date.Format("02/01/2006"))
+		ticket := entry.TicketURL
+		if label := entry.Ticket.Label(); label != "" {
+			ticket = label
+		}
+		doc.text(pdfTicketX+5, y+5, pdfFontSize, false, pdfBlack, fitText(ticket, pdfFontSize, pdfMinutesX-pdfTicketX-80))
+		doc.textRight(pdfMinutesX-5, y+5, pdfFontSize, false, pdfBlack, e.options.Duration.Format(entry.Minutes))
+	}
+
+	// Write total row
+	y = e.nextRow(doc, y)
+	doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfTotalRGB)
+	doc.text(pdfDateX+5, y+5, pdfFontSize, true, pdfBlack, "TOTAL")
+	doc.text(pdfTicketX+5, y+5, pdfFontSize, true, pdfBlack, entities.FormatHoursMinutes(report.TotalTime)+" horas")
+	doc.textRight(pdfMinutesX-5, y+5, pdfFontSize, true, pdfBlack, e.options.Duration.Format(report.TotalTime))
+
+	// Billable minutes follow the total when client rounding policies apply
+	if report.Rounding.Enabled() {
+		y = e.nextRow(doc, y)
+		doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfTotalRGB)
+		doc.text(pdfDateX+5, y+5, pdfFontSize, true, pdfBlack, "FATURÁVEL")
+		doc.textRight(pdfMinutesX-5, y+5, pdfFontSize, true, pdfBlack, e.options.Duration.Format(report.BillableTime))
+	}
+
+	// Weighted hours are only printed when entries carry multipliers
+	if breakdown := report.WeightedBreakdown(); breakdown != nil {
+		needed := pdfRowHeight * float64(len(breakdown)+4)
+		if y-needed < pdfFooterY {
+			doc.addPage()
+			y = pdfPageHeight - pdfMargin
+		}
+		y = drawWeightedBreakdown(doc, y-30, breakdown, report.TotalWeightedMinutes())
+	}
+
+	if len(report.Violations) > 0 {
+		y = e.drawViolations(doc, y-30, report.Violations)
+	}
+
+	// Pending entries are not part of the signed timesheet, only mentioned
+	if len(report.Pending) > 0 {
+		if y-30 < pdfFooterY {
+			doc.addPage()
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= 20
+		doc.text(pdfMargin, y, pdfFontSize, false, pdfBlack, fmt.Sprintf("%d lançamentos pendentes de aprovação (%s) não incluídos",
+			len(report.Pending), e.options.Duration.Format(report.PendingMinutes())))
+	}
+
+	e.drawSignatures(doc)
+
+	if err := doc.write(w); err != nil {
+		return fmt.Errorf("error writing PDF file: %w", err)
+	}
+	return nil
+}
+
+// drawHeader draws the company, employee and period, and returns the y below them
+func (e *PDFReportExporter) drawHeader(doc *pdfDocument, report *entities.OvertimeReport) float64 {
+	y := pdfPageHeight - pdfMargin - 10
+	if e.options.Company != "" {
+		doc.text(pdfMargin, y, 16, true, pdfBlack, e.options.Company)
+		y -= 24
+	}
+	doc.text(pdfMargin, y, 14, true, pdfHeaderRGB, "Relatório de Horas Extras")
+	y -= 22
+	if e.options.Employee != "" {
+		doc.text(pdfMargin, y, pdfFontSize, false, pdfBlack, "Colaborador: "+e.options.Employee)
+		y -= 15
+	}
+	doc.text(pdfMargin, y, pdfFontSize, false, pdfBlack, "Período: "+report.Period)
+	y -= 15
+	doc.text(pdfMargin, y, pdfFontSize, false, pdfBlack, "Emitido em: "+report.ReportDate.Format("02/01/2006"))
+	return y - 20
+}
+
+// drawTableHeader draws the entry table header below y and returns its bottom
+func (e *PDFReportExporter) drawTableHeader(doc *pdfDocument, y float64) float64 {
+	y -= pdfRowHeight
+	doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfHeaderRGB)
+	doc.text(pdfDateX+5, y+5, pdfFontSize, true, pdfWhite, "DATA")
+	doc.text(pdfTicketX+5, y+5, pdfFontSize, true, pdfWhite, "TICKET")
+	doc.textRight(pdfMinutesX-5, y+5, pdfFontSize, true, pdfWhite, durationHeader(e.options.Duration))
+	return y
+}
+
+// nextRow returns the bottom of the table row below y, continuing the table on a new page
+// when the row would reach the signature block
+func (e *PDFReportExporter) nextRow(doc *pdfDocument, y float64) float64 {
+	if y-pdfRowHeight < pdfFooterY {
+		doc.addPage()
+		y = e.drawTableHeader(doc, pdfPageHeight-pdfMargin)
+	}
+	return y - pdfRowHeight
+}
+
+// drawWeightedBreakdown draws the minutes per multiplier below y and returns the bottom
+func drawWeightedBreakdown(doc *pdfDocument, y float64, breakdown []entities.WeightedGroup, total float64) float64 {
+	multiplierX := pdfMargin
+	minutesX := pdfMargin + 250
+	weightedX := pdfTableEnd
+
+	doc.text(pdfMargin, y, 12, true, pdfBlack, "Horas ponderadas")
+	y -= 8 + pdfRowHeight
+	doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfHeaderRGB)
+	doc.text(multiplierX+5, y+5, pdfFontSize, true, pdfWhite, "MULTIPLICADOR")
+	doc.textRight(minutesX-5, y+5, pdfFontSize, true, pdfWhite, "MINUTOS")
+	doc.textRight(weightedX-5, y+5, pdfFontSize, true, pdfWhite, "MINUTOS PONDERADOS")
+
+	for _, group := range breakdown {
+		y -= pdfRowHeight
+		doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfWhite)
+		doc.text(multiplierX+5, y+5, pdfFontSize, false, pdfBlack, strconv.FormatFloat(group.Multiplier, 'f', -1, 64)+"x")
+		doc.textRight(minutesX-5, y+5, pdfFontSize, false, pdfBlack, strconv.Itoa(group.Minutes))
+		doc.textRight(weightedX-5, y+5, pdfFontSize, false, pdfBlack, strconv.FormatFloat(group.WeightedMinutes, 'f', 1, 64))
+	}
+
+	y -= pdfRowHeight
+	doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfTotalRGB)
+	doc.text(multiplierX+5, y+5, pdfFontSize, true, pdfBlack, "TOTAL")
+	doc.textRight(weightedX-5, y+5, pdfFontSize, true, pdfBlack, strconv.FormatFloat(total, 'f', 1, 64))
+	return y
+}
+
+// drawViolations draws the overtime limit violations below y, continuing on new pages, and returns the bottom
+func (e *PDFReportExporter) drawViolations(doc *pdfDocument, y float64, violations []entities.PolicyViolation) float64 {
+	ownerX := pdfMargin + 70
+	dateX := pdfMargin + 270
+	minutesX := pdfTableEnd - 100
+
+	header := func(y float64) float64 {
+		y -= pdfRowHeight
+		doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfHeaderRGB)
+		doc.text(pdfMargin+5, y+5, pdfFontSize, true, pdfWhite, "TIPO")
+		doc.text(ownerX+5, y+5, pdfFontSize, true, pdfWhite, "RESPONSÁVEL")
+		doc.text(dateX+5, y+5, pdfFontSize, true, pdfWhite, "DATA")
+		doc.textRight(minutesX-5, y+5, pdfFontSize, true, pdfWhite, durationHeader(e.options.Duration))
+		doc.textRight(pdfTableEnd-5, y+5, pdfFontSize, true, pdfWhite, "LIMITE")
+		return y
+	}
+
+	if y-8-2*pdfRowHeight < pdfFooterY {
+		doc.addPage()
+		y = pdfPageHeight - pdfMargin
+	}
+	doc.text(pdfMargin, y, 12, true, pdfBlack, "Limites excedidos")
+	y = header(y - 8)
+
+	for _, violation := range violations {
+		if y-pdfRowHeight < pdfFooterY {
+			doc.addPage()
+			y = header(pdfPageHeight - pdfMargin)
+		}
+		when := violation.Period
+		if violation.Kind == entities.ViolationDaily {
+			when = violation.Date.Format("02/01/2006")
+		}
+
+		y -= pdfRowHeight
+		doc.rect(pdfMargin, y, pdfTableEnd-pdfMargin, pdfRowHeight, pdfWhite)
+		doc.text(pdfMargin+5, y+5, pdfFontSize, false, pdfBlack, violationKindLabel(violation.Kind))
+		doc.text(ownerX+5, y+5, pdfFontSize, false, pdfBlack, fitText(violation.Owner, pdfFontSize, dateX-ownerX-10))
+		doc.text(dateX+5, y+5, pdfFontSize, false, pdfBlack, when)
+		doc.textRight(minutesX-5, y+5, pdfFontSize, false, pdfBlack, e.options.Duration.Format(violation.Minutes))
+		doc.textRight(pdfTableEnd-5, y+5, pdfFontSize, false, pdfBlack, e.options.Duration.Format(violation.Limit))
+	}
+	return y
+}
+
+// drawSignatures draws the employee and approval signature lines at the bottom of the last page
+func (e *PDFReportExporter) drawSignatures(doc *pdfDocument) {
+	lineWidth := 200.0
+	y := 90.0
+	leftX := pdfMargin
+	rightX := pdfPageWidth - pdfMargin - lineWidth
+
+	doc.line(leftX, y, leftX+lineWidth, y, 0.7)
+	doc.line(rightX, y, rightX+lineWidth, y, 0.7)
+
+	doc.text(leftX, y-14, pdfFontSize, true, pdfBlack, "Colaborador")
+	doc.text(rightX, y-14, pdfFontSize, true, pdfBlack, "Aprovação")
+	if e.options.Employee != "" {
+		doc.text(leftX, y-28, pdfFontSize, false, pdfBlack, e.options.Employee)
+	}
+	if e.options.Approver != "" {
+		doc.text(rightX, y-28, pdfFontSize, false, pdfBlack, e.options.Approver)
+	}
+	doc.text(leftX, y-42, pdfFontSize, false, pdfBlack, "Data: ____/____/______")
+	doc.text(rightX, y-42, pdfFontSize, false, pdfBlack, "Data: ____/____/______")
+}
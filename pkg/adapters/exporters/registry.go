@@ -0,0 +1,83 @@
+package exporters
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// FormatExporter writes reports in a single format
+type FormatExporter interface {
+	// Format returns the metadata of the produced format
+	Format() entities.ExportFormat
+
+	// Export writes the report to w
+	Export(ctx context.Context, report *entities.OvertimeReport, w io.Writer) error
+}
+
+// ExporterRegistry implements the ReportExporter interface by dispatching to the
+// exporter registered for each format name
+type ExporterRegistry struct {
+	exporters map[string]FormatExporter
+	order     []string
+}
+
+// NewExporterRegistry creates a registry with the given exporters
+func NewExporterRegistry(exporters ...FormatExporter) *ExporterRegistry {
+	registry := &ExporterRegistry{
+		exporters: make(map[string]FormatExporter),
+	}
+	for _, exporter := range exporters {
+		registry.Register(exporter)
+	}
+	return registry
+}
+
+// NewDefaultExporterRegistry creates a registry with the built-in Excel, CSV, JSON, NDJSON and ODS exporters
+func NewDefaultExporterRegistry() *ExporterRegistry {
+	return NewExporterRegistry(
+		NewExcelReportExporter(ExcelOptions{}),
+		NewCSVReportExporter(CSVOptions{}),
+		NewJSONReportExporter(),
+		NewNDJSONReportExporter(),
+		NewODSReportExporter(ODSOptions{}),
+	)
+}
+
+// Register adds an exporter, replacing any exporter registered for the same format name
+func (r *ExporterRegistry) Register(exporter FormatExporter) {
+	name := exporter.Format().Name
+	if _, ok := r.exporters[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.exporters[name] = exporter
+}
+
+// Export writes the report to w in the named format
+func (r *ExporterRegistry) Export(ctx context.Context, report *entities.OvertimeReport, format string, w io.Writer) error {
+	exporter, ok := r.exporters[format]
+	if !ok {
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+	return exporter.Export(ctx, report, w)
+}
+
+// Format returns the metadata of the named format
+func (r *ExporterRegistry) Format(name string) (entities.ExportFormat, error) {
+	exporter, ok := r.exporters[name]
+	if !ok {
+		return entities.ExportFormat{}, fmt.Errorf("unsupported report format %q", name)
+	}
+	return exporter.Format(), nil
+}
+
+// Formats lists the registered formats in registration order
+func (r *ExporterRegistry) Formats() []entities.ExportFormat {
+	formats := make([]entities.ExportFormat, 0, len(r.order))
+	for _, name := range r.order {
+		formats = append(formats, r.exporters[name].Format())
+	}
+	return formats
+}
@@ -0,0 +1,101 @@
+package importers
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// maxRepositoryDepth bounds how deep repositories are searched below the given paths
+const maxRepositoryDepth = 3
+
+// gitClockSkew widens the committer date window of git log, since commits can be committed
+// slightly before their author time on machines with skewed clocks
+const gitClockSkew = 24 * time.Hour
+
+// gitLogFormat writes the hash, author, email, author time and message of each commit,
+// with unit separators between fields and record separators between commits
+const gitLogFormat = "%H%x1f%an%x1f%ae%x1f%aI%x1f%B%x1e"
+
+// FindGitRepositories returns the git repositories at the given paths or in their
+// subdirectories, a few levels deep
+func FindGitRepositories(paths []string) ([]string, error) {
+	var repositories []string
+	for _, root := range paths {
+		root = filepath.Clean(root)
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() {
+				return nil
+			}
+			// .git is a directory in clones and a file in worktrees
+			if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+				repositories = append(repositories, path)
+				return filepath.SkipDir
+			}
+			if path != root && (strings.HasPrefix(entry.Name(), ".") || strings.Count(strings.TrimPrefix(path, root), string(filepath.Separator)) >= maxRepositoryDepth) {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error searching repositories in %s: %w", root, err)
+		}
+	}
+	return repositories, nil
+}
+
+// ReadGitCommits reads the commits of an author, matched literally in the name or email as
+// git log --author --fixed-strings does, on every branch of the repositories, authored
+// between since and until. Merge commits are left out, and commits found in several clones
+// are read once.
+func ReadGitCommits(ctx context.Context, repositories []string, author string, since, until time.Time) ([]entities.Commit, error) {
+	var commits []entities.Commit
+	seen := make(map[string]bool)
+	for _, repository := range repositories {
+		// git log filters on the committer date, which rebases and cherry-picks move after
+		// the author time, so only the start bounds the log and the author time is checked below
+		cmd := exec.CommandContext(ctx, "git", "-C", repository, "log", "--all", "--no-merges",
+			"--fixed-strings",
+			"--author="+author,
+			"--since="+since.Add(-gitClockSkew).Format(time.RFC3339),
+			"--format="+gitLogFormat)
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("error reading the git log of %s: %w", repository, err)
+		}
+
+		for _, record := range strings.Split(string(output), "\x1e") {
+			fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+			if len(fields) != 5 || seen[fields[0]] {
+				continue
+			}
+			authored, err := time.Parse(time.RFC3339, fields[3])
+			if err != nil {
+				return nil, fmt.Errorf("error reading commit %s of %s: %w", fields[0], repository, err)
+			}
+			seen[fields[0]] = true
+			if authored.Before(since) || !authored.Before(until) {
+				continue
+			}
+			commits = append(commits, entities.Commit{
+				Hash:       fields[0],
+				Repository: filepath.Base(repository),
+				Author:     fields[1],
+				Email:      fields[2],
+				Time:       authored,
+				Message:    strings.TrimSpace(fields[4]),
+			})
+		}
+	}
+	return commits, nil
+}
@@ -0,0 +1,491 @@
+package importers
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// icsProperty is a content line of an iCalendar component
+type icsProperty struct {
+	Params map[string]string
+	Value  string
+}
+
+// icsText unescapes iCalendar text values
+var icsText = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";")
+
+// icsDurationPattern matches iCalendar durations such as PT1H30M or P1D
+var icsDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseICS reads the VEVENTs of an iCalendar file, e.g. on-call interventions recorded as
+// calendar events. Events are dated from DTSTART to DTEND, or DURATION, in the time zone of
+// their TZID or UTC; floating times and time zones unknown to the system, such as Windows
+// names, use the options location. Recurring events are expanded into their occurrences in
+// the options range, or up to now without an end, following their RRULE, RDATE and EXDATE
+// and leaving out the occurrences modified by a RECURRENCE-ID, which are read as events of
+// their own. The event UID identifies the entry, followed by the occurrence start for
+// occurrences of recurring events. Cancelled, all-day and out of range events, and recurring
+// events with unsupported rules, are returned as skipped.
+func ParseICS(r io.Reader, options TrackerOptions) ([]entities.TrackedEntry, []entities.SkippedEntry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading iCalendar file: %w", err)
+	}
+	location := options.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	// Events are read first, since modified occurrences may come before their series
+	var events []map[string][]icsProperty
+	var event map[string][]icsProperty
+	nested := 0
+	for _, line := range unfoldICS(string(content)) {
+		name, property := parseICSLine(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(property.Value, "VEVENT"):
+			event = make(map[string][]icsProperty)
+			nested = 0
+		case event == nil:
+		case name == "BEGIN":
+			// Alarms and other nested components do not describe the event
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case name == "END" && strings.EqualFold(property.Value, "VEVENT"):
+			events = append(events, event)
+			event = nil
+		case nested == 0:
+			event[name] = append(event[name], property)
+		}
+	}
+
+	overridden := make(map[string]bool)
+	for _, event := range events {
+		if uid, recurrence := icsValue(event, "UID"), event["RECURRENCE-ID"]; uid != "" && len(recurrence) > 0 {
+			if at, _, err := parseICSTime(recurrence[0], location); err == nil {
+				overridden[icsOccurrenceID(uid, at)] = true
+			}
+		}
+	}
+
+	var tracked []entities.TrackedEntry
+	var skipped []entities.SkippedEntry
+	for i, event := range events {
+		entry, reason, err := icsEntry(event, location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("event %d: %w", i+1, err)
+		}
+		entry.Line = i + 1
+		skip := func(reason string) {
+			skipped = append(skipped, entities.SkippedEntry{Entry: entry, Reason: reason})
+		}
+		if reason != "" {
+			skip(reason)
+			continue
+		}
+
+		recurring := len(event["RRULE"]) > 0 || len(event["RDATE"]) > 0
+		if !recurring || len(event["RECURRENCE-ID"]) > 0 {
+			if !options.inRange(entry.Start) {
+				skip(outOfRange)
+				continue
+			}
+			tracked = append(tracked, entry)
+			continue
+		}
+
+		occurrences, err := icsOccurrences(event, entry.Start, location, options)
+		if err != nil {
+			skip(err.Error())
+			continue
+		}
+		duration := entry.End.Sub(entry.Start)
+		found := 0
+		for _, start := range occurrences {
+			id := icsOccurrenceID(icsValue(event, "UID"), start)
+			if overridden[id] || !options.inRange(start) {
+				continue
+			}
+			occurrence := entry
+			occurrence.Start, occurrence.End, occurrence.ID = start, start.Add(duration), id
+			tracked = append(tracked, occurrence)
+			found++
+		}
+		if found == 0 {
+			skip("recurring event without occurrences in the imported range")
+		}
+	}
+	return tracked, skipped, nil
+}
+
+// icsValue returns the unescaped value of the first property of an event with the given name
+func icsValue(event map[string][]icsProperty, name string) string {
+	if properties := event[name]; len(properties) > 0 {
+		return strings.TrimSpace(icsText.Replace(properties[0].Value))
+	}
+	return ""
+}
+
+// icsOccurrenceID identifies an occurrence of a recurring event by its UID and UTC start,
+// e.g. oncall@example.com/20260901T220000Z, or "" for events without a UID
+func icsOccurrenceID(uid string, start time.Time) string {
+	if uid == "" {
+		return ""
+	}
+	return uid + "/" + start.UTC().Format("20060102T150405Z")
+}
+
+// icsEntry converts an event, returning why it is left out instead for cancelled and
+// all-day events
+func icsEntry(event map[string][]icsProperty, location *time.Location) (entities.TrackedEntry, string, error) {
+	value := func(name string) string {
+		return icsValue(event, name)
+	}
+	entry := entities.TrackedEntry{Description: strings.Join(strings.Fields(value("SUMMARY")), " ")}
+	if len(event["DTSTART"]) == 0 {
+		return entry, "event without a start", nil
+	}
+
+	start, allDay, err := parseICSTime(event["DTSTART"][0], location)
+	if err != nil {
+		return entry, "", fmt.Errorf("invalid DTSTART: %w", err)
+	}
+	entry.Start = start
+	if strings.EqualFold(value("STATUS"), "CANCELLED") {
+		return entry, "cancelled event", nil
+	}
+	if allDay {
+		return entry, "all-day event", nil
+	}
+
+	end := start
+	switch {
+	case len(event["DTEND"]) > 0:
+		if end, _, err = parseICSTime(event["DTEND"][0], location); err != nil {
+			return entry, "", fmt.Errorf("invalid DTEND: %w", err)
+		}
+	case value("DURATION") != "":
+		duration, err := parseICSDuration(value("DURATION"))
+		if err != nil {
+			return entry, "", err
+		}
+		end = start.Add(duration)
+	}
+
+	id := value("UID")
+	if recurrence := event["RECURRENCE-ID"]; id != "" && len(recurrence) > 0 {
+		at, _, err := parseICSTime(recurrence[0], location)
+		if err != nil {
+			return entry, "", fmt.Errorf("invalid RECURRENCE-ID: %w", err)
+		}
+		id = icsOccurrenceID(id, at)
+	}
+	var tags []string
+	for _, categories := range event["CATEGORIES"] {
+		// Commas separate categories, escaped commas belong to a name
+		for _, tag := range strings.Split(strings.ReplaceAll(categories.Value, `\,`, "\x00"), ",") {
+			if tag = strings.TrimSpace(strings.ReplaceAll(icsText.Replace(tag), "\x00", ",")); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	organizer := value("ORGANIZER")
+	if len(organizer) > len("mailto:") && strings.EqualFold(organizer[:len("mailto:")], "mailto:") {
+		organizer = organizer[len("mailto:"):]
+	}
+
+	entry.End = end
+	entry.Tags = tags
+	entry.User = organizer
+	entry.ID = id
+	entry.URL = value("URL")
+	entry.Notes = value("DESCRIPTION")
+	return entry, "", nil
+}
+
+// unfoldICS splits iCalendar content in content lines, joining the folded lines that start
+// with a space or a tab
+func unfoldICS(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICSLine splits a content line such as DTSTART;TZID=America/Sao_Paulo:20260901T200000
+// in its upper case name, parameters and value
+func parseICSLine(line string) (string, icsProperty) {
+	quoted := false
+	colon := -1
+	for i, char := range line {
+		if char == '"' {
+			quoted = !quoted
+		} else if char == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return strings.ToUpper(line), icsProperty{}
+	}
+
+	property := icsProperty{Params: make(map[string]string), Value: line[colon+1:]}
+	parts := strings.Split(line[:colon], ";")
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			property.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), property
+}
+
+// parseICSTime reads a DATE-TIME in UTC, in the time zone of its TZID or floating, and
+// reports DATE values as all-day
+func parseICSTime(property icsProperty, location *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(property.Value)
+	if strings.EqualFold(property.Params["VALUE"], "DATE") || len(value) == len("20060102") {
+		day, err := time.ParseInLocation("20060102", value, location)
+		return day, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		return parsed, false, err
+	}
+	if tzid := property.Params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			location = zone
+		}
+	}
+	parsed, err := time.ParseInLocation("20060102T150405", value, location)
+	return parsed, false, err
+}
+
+// parseICSDuration reads a DURATION value such as PT1H30M
+func parseICSDuration(value string) (time.Duration, error) {
+	match := icsDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	var duration time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if count, err := strconv.Atoi(match[i+2]); err == nil {
+			duration += time.Duration(count) * unit
+		}
+	}
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+// icsRule is a recurrence rule of the supported subset: a frequency with its interval,
+// bounded by a count or an end, and the week days of weekly rules
+type icsRule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+// icsWeekdays maps the iCalendar week days to Go week days
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// icsOccurrences returns the starts of the occurrences of a recurring event from its first
+// start up to the end of the options range, or up to now when the range has no end.
+// Excluded dates are left out.
+func icsOccurrences(event map[string][]icsProperty, start time.Time, location *time.Location, options TrackerOptions) ([]time.Time, error) {
+	limit := options.To
+	if limit.IsZero() {
+		limit = time.Now()
+	}
+
+	occurrences := []time.Time{}
+	for _, property := range event["RRULE"] {
+		rule, err := parseICSRule(property.Value, location)
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, rule.expand(start, limit)...)
+	}
+	if len(event["RRULE"]) == 0 {
+		occurrences = append(occurrences, start)
+	}
+	for _, property := range event["RDATE"] {
+		if strings.EqualFold(property.Params["VALUE"], "PERIOD") {
+			return nil, fmt.Errorf("unsupported RDATE periods")
+		}
+		dates, err := parseICSTimes(property, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RDATE: %w", err)
+		}
+		occurrences = append(occurrences, dates...)
+	}
+
+	excluded := make(map[int64]bool)
+	for _, property := range event["EXDATE"] {
+		dates, err := parseICSTimes(property, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXDATE: %w", err)
+		}
+		for _, date := range dates {
+			excluded[date.Unix()] = true
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	var result []time.Time
+	for _, occurrence := range occurrences {
+		if excluded[occurrence.Unix()] || (len(result) > 0 && result[len(result)-1].Equal(occurrence)) {
+			continue
+		}
+		result = append(result, occurrence)
+	}
+	return result, nil
+}
+
+// parseICSTimes reads the comma separated times of an RDATE or EXDATE property
+func parseICSTimes(property icsProperty, location *time.Location) ([]time.Time, error) {
+	var times []time.Time
+	for _, value := range strings.Split(property.Value, ",") {
+		parsed, _, err := parseICSTime(icsProperty{Params: property.Params, Value: value}, location)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, parsed)
+	}
+	return times, nil
+}
+
+// parseICSRule reads an RRULE such as FREQ=WEEKLY;INTERVAL=2;BYDAY=SA,SU;UNTIL=20261231T235959Z.
+// Rules with other parts, such as BYMONTHDAY or BYSETPOS, are not supported.
+func parseICSRule(value string, location *time.Location) (icsRule, error) {
+	rule := icsRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, setting, _ := strings.Cut(part, "=")
+		key, setting = strings.ToUpper(strings.TrimSpace(key)), strings.ToUpper(strings.TrimSpace(setting))
+		switch key {
+		case "FREQ":
+			rule.Freq = setting
+		case "INTERVAL", "COUNT":
+			number, err := strconv.Atoi(setting)
+			if err != nil || number <= 0 {
+				return rule, fmt.Errorf("invalid recurrence %s %q", key, setting)
+			}
+			if key == "INTERVAL" {
+				rule.Interval = number
+			} else {
+				rule.Count = number
+			}
+		case "UNTIL":
+			until, date, err := parseICSTime(icsProperty{Value: setting}, location)
+			if err != nil {
+				return rule, fmt.Errorf("invalid recurrence UNTIL %q", setting)
+			}
+			if date {
+				until = until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(setting, ",") {
+				weekday, ok := icsWeekdays[day]
+				if !ok {
+					return rule, fmt.Errorf("unsupported recurrence BYDAY %q", setting)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "WKST", "":
+		default:
+			return rule, fmt.Errorf("unsupported recurrence rule %s", key)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return rule, fmt.Errorf("unsupported recurrence frequency %q", rule.Freq)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != "WEEKLY" {
+		return rule, fmt.Errorf("unsupported recurrence BYDAY in %s rules", rule.Freq)
+	}
+	return rule, nil
+}
+
+// expand returns the occurrences of the rule starting at start, before limit
+func (r icsRule) expand(start, limit time.Time) []time.Time {
+	var occurrences []time.Time
+	for period := 0; ; period++ {
+		for _, occurrence := range r.period(start, period*r.Interval) {
+			if occurrence.Before(start) {
+				continue
+			}
+			if !occurrence.Before(limit) || (!r.Until.IsZero() && occurrence.After(r.Until)) || (r.Count > 0 && len(occurrences) == r.Count) {
+				return occurrences
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+		// Periods without occurrences, e.g. months without the 31st, still end the expansion
+		if !r.shift(start, period*r.Interval).Before(limit) {
+			return occurrences
+		}
+	}
+}
+
+// shift moves the start the given number of frequency units, keeping its time of day across
+// daylight saving changes
+func (r icsRule) shift(start time.Time, units int) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		return start.AddDate(0, 0, 7*units)
+	case "MONTHLY":
+		return start.AddDate(0, units, 0)
+	case "YEARLY":
+		return start.AddDate(units, 0, 0)
+	default:
+		return start.AddDate(0, 0, units)
+	}
+}
+
+// period returns the occurrences of the rule in the period the given number of frequency
+// units after the start
+func (r icsRule) period(start time.Time, units int) []time.Time {
+	day := r.shift(start, units)
+	switch {
+	case r.Freq == "WEEKLY" && len(r.ByDay) > 0:
+		monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		var days []time.Time
+		for offset := 0; offset < 7; offset++ {
+			day := monday.AddDate(0, 0, offset)
+			for _, weekday := range r.ByDay {
+				if day.Weekday() == weekday {
+					days = append(days, day)
+					break
+				}
+			}
+		}
+		return days
+	case (r.Freq == "MONTHLY" || r.Freq == "YEARLY") && day.Day() != start.Day():
+		// Months without the day of the start, e.g. the 31st, have no occurrence
+		return nil
+	default:
+		return []time.Time{day}
+	}
+}
@@ -0,0 +1,339 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// TrackerOptions configures how time tracker exports are read
+type TrackerOptions struct {
+	// Location of the dates and times of CSV exports, UTC if nil. JSON exports carry their offsets.
+	Location *time.Location
+	// DateLayout of CSV dates when they are not ISO dates, 01/02/2006 by default as in Clockify
+	DateLayout string
+	// From and To limit the entries read to those starting within the range, when set. Entries
+	// outside the range are returned as skipped.
+	From time.Time
+	To   time.Time
+}
+
+// outOfRange is the reason of the entries skipped for starting outside the options range
+const outOfRange = "outside the imported range"
+
+// inRange reports whether an entry starting at the given time is within the options range
+func (o TrackerOptions) inRange(start time.Time) bool {
+	return (o.From.IsZero() || !start.Before(o.From)) && (o.To.IsZero() || start.Before(o.To))
+}
+
+// csvColumns lists the header names of each field in Toggl and Clockify detailed CSV reports
+var csvColumns = map[string][]string{
+	"start":       {"start"},
+	"start date":  {"start date"},
+	"start time":  {"start time"},
+	"end":         {"end", "stop"},
+	"end date":    {"end date", "stop date"},
+	"end time":    {"end time", "stop time"},
+	"duration":    {"duration", "duration (h)"},
+	"project":     {"project"},
+	"description": {"description"},
+	"tags":        {"tags", "tag"},
+	"user":        {"email", "user email", "user"},
+}
+
+// clockLayouts are the time of day formats of the exports, 24 hour and 12 hour clocks
+var clockLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "3:04:05 PM", "03:04 PM", "3:04 PM"}
+
+// ParseTrackerExport reads a Toggl or Clockify export, as JSON when the file name ends in
+// .json, as calendar events when it ends in .ics and as a detailed CSV report otherwise.
+// The entries left out while reading, e.g. outside the options range, are returned as skipped.
+func ParseTrackerExport(r io.Reader, name string, options TrackerOptions) ([]entities.TrackedEntry, []entities.SkippedEntry, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ParseTrackerJSON(r, options)
+	case ".ics":
+		return ParseICS(r, options)
+	default:
+		return ParseTrackerCSV(r, options)
+	}
+}
+
+// ParseTrackerCSV reads the detailed CSV report of Toggl Track or Clockify. Columns are found
+// by header name, so both layouts and reordered columns are accepted.
+func ParseTrackerCSV(r io.Reader, options TrackerOptions) ([]entities.TrackedEntry, []entities.SkippedEntry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading CSV export: %w", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	// Exports of European locales separate fields with semicolons
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading CSV export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("the CSV export is empty")
+	}
+
+	headers := make(map[string]int)
+	for i, header := range records[0] {
+		headers[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	// The first header name found wins, e.g. the email of Toggl reports over the user name
+	columns := make(map[string]int)
+	for field, names := range csvColumns {
+		for _, name := range names {
+			if i, ok := headers[name]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	_, hasStart := columns["start"]
+	_, hasStartDate := columns["start date"]
+	if !hasStart && !hasStartDate {
+		return nil, nil, fmt.Errorf("the CSV export has no start date column")
+	}
+
+	location := options.Location
+	if location == nil {
+		location = time.UTC
+	}
+	dateLayout := options.DateLayout
+	if dateLayout == "" {
+		dateLayout = "01/02/2006"
+	}
+
+	var tracked []entities.TrackedEntry
+	var skipped []entities.SkippedEntry
+	for i, record := range records[1:] {
+		field := func(name string) string {
+			if col, ok := columns[name]; ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		line := i + 1
+		start, err := parseTrackedTime(field("start"), field("start date"), field("start time"), dateLayout, location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: invalid start: %w", line, err)
+		}
+		end, err := parseTrackedTime(field("end"), field("end date"), field("end time"), dateLayout, location)
+		if err != nil {
+			duration, durationErr := parseTrackedDuration(field("duration"))
+			if durationErr != nil {
+				return nil, nil, fmt.Errorf("line %d: invalid end: %w", line, err)
+			}
+			end = start.Add(duration)
+		}
+
+		entry := entities.TrackedEntry{
+			Start:       start,
+			End:         end,
+			Project:     field("project"),
+			Tags:        splitTags(field("tags")),
+			Description: field("description"),
+			User:        field("user"),
+			Line:        line,
+		}
+		if !options.inRange(start) {
+			skipped = append(skipped, entities.SkippedEntry{Entry: entry, Reason: outOfRange})
+			continue
+		}
+		tracked = append(tracked, entry)
+	}
+	return tracked, skipped, nil
+}
+
+// parseTrackedTime reads a combined date and time, or a date and a time of day from separate columns
+func parseTrackedTime(combined, date, clock, dateLayout string, location *time.Location) (time.Time, error) {
+	if combined != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04"} {
+			if parsed, err := time.ParseInLocation(layout, combined, location); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%q is not a date and time", combined)
+	}
+	if date == "" {
+		return time.Time{}, fmt.Errorf("missing date")
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, location)
+	if err != nil {
+		if day, err = time.ParseInLocation(dateLayout, date, location); err != nil {
+			return time.Time{}, fmt.Errorf("%q does not match the date layout %s", date, dateLayout)
+		}
+	}
+	if clock == "" {
+		return day, nil
+	}
+	for _, layout := range clockLayouts {
+		if parsed, err := time.Parse(layout, strings.ToUpper(clock)); err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, location), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a time of day", clock)
+}
+
+// parseTrackedDuration reads a duration as HH:MM:SS, or as decimal hours
+func parseTrackedDuration(value string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &hours, &minutes, &seconds); err == nil {
+		return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+	}
+	var decimal float64
+	if _, err := fmt.Sscanf(strings.Replace(value, ",", ".", 1), "%g", &decimal); err == nil && decimal > 0 {
+		return time.Duration(decimal * float64(time.Hour)).Round(time.Second), nil
+	}
+	return 0, fmt.Errorf("invalid duration %q", value)
+}
+
+// splitTags splits a comma separated tag list
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ParseTrackerJSON reads time entries of the Toggl Track and Clockify APIs and JSON reports:
+// an array of entries, or an object holding them in a timeentries, time_entries or data field
+func ParseTrackerJSON(r io.Reader, options TrackerOptions) ([]entities.TrackedEntry, []entities.SkippedEntry, error) {
+	var document interface{}
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("error reading JSON export: %w", err)
+	}
+
+	items, ok := document.([]interface{})
+	if object, isObject := document.(map[string]interface{}); isObject {
+		for _, key := range []string{"timeentries", "timeEntries", "time_entries", "data"} {
+			if items, ok = object[key].([]interface{}); ok {
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("the JSON export holds no list of time entries")
+	}
+
+	var tracked []entities.TrackedEntry
+	var skipped []entities.SkippedEntry
+	for i, item := range items {
+		object, _ := item.(map[string]interface{})
+		line := i + 1
+		interval, _ := object["timeInterval"].(map[string]interface{})
+
+		start, err := parseJSONTime(firstString(object, "start"), firstString(interval, "start"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("entry %d: invalid start: %w", line, err)
+		}
+		end, err := parseJSONTime(firstString(object, "stop", "end"), firstString(interval, "end"))
+		if err != nil {
+			// Toggl writes the duration in seconds, negative while the timer runs, and its
+			// reports in milliseconds
+			if seconds, ok := object["duration"].(float64); ok && seconds > 0 {
+				end = start.Add(time.Duration(seconds) * time.Second)
+			} else if millis, ok := object["dur"].(float64); ok && millis > 0 {
+				end = start.Add(time.Duration(millis) * time.Millisecond)
+			} else {
+				return nil, nil, fmt.Errorf("entry %d: invalid end: %w", line, err)
+			}
+		}
+
+		project := firstString(object, "project", "project_name", "projectName")
+		if nested, ok := object["project"].(map[string]interface{}); ok {
+			project = firstString(nested, "name")
+		}
+
+		entry := entities.TrackedEntry{
+			Start:       start,
+			End:         end,
+			Project:     project,
+			Tags:        jsonTags(object),
+			Description: firstString(object, "description"),
+			User:        firstString(object, "user_email", "userEmail", "email", "user", "userName", "user_name", "username"),
+			Line:        line,
+			ID:          jsonID(object),
+		}
+		if !options.inRange(start) {
+			skipped = append(skipped, entities.SkippedEntry{Entry: entry, Reason: outOfRange})
+			continue
+		}
+		tracked = append(tracked, entry)
+	}
+	return tracked, skipped, nil
+}
+
+// jsonID reads the identifier of an entry, a number in Toggl and a string in Clockify
+func jsonID(object map[string]interface{}) string {
+	switch id := object["id"].(type) {
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	case string:
+		return id
+	}
+	return firstString(object, "_id")
+}
+
+// parseJSONTime reads the first non-empty RFC 3339 time
+func parseJSONTime(values ...string) (time.Time, error) {
+	for _, value := range values {
+		if value != "" {
+			return time.Parse(time.RFC3339, value)
+		}
+	}
+	return time.Time{}, fmt.Errorf("missing time")
+}
+
+// firstString returns the first string field found among the given names
+func firstString(object map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if value, ok := object[name].(string); ok && value != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// jsonTags reads the tags of an entry, given as names or as tag objects
+func jsonTags(object map[string]interface{}) []string {
+	var tags []string
+	for _, key := range []string{"tags", "tag_names"} {
+		list, _ := object[key].([]interface{})
+		for _, tag := range list {
+			switch value := tag.(type) {
+			case string:
+				tags = append(tags, value)
+			case map[string]interface{}:
+				if name := firstString(value, "name"); name != "" {
+					tags = append(tags, name)
+				}
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// ArchiveService implements the NotificationService interface by copying report
+// attachments into a directory, one sub-directory per period
+type ArchiveService struct {
+	directory string
+}
+
+// NewArchiveService creates a new archive notification service
+func NewArchiveService(directory string) repositories.NotificationService {
+	return &ArchiveService{
+		directory: directory,
+	}
+}
+
+// SendReportByEmail archives the report attachments
+func (s *ArchiveService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
+	periodDir := filepath.Join(s.directory, strings.ToLower(report.Period))
+	if err := os.MkdirAll(periodDir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating archive directory: %w", err)
+	}
+
+	receipt := &entities.DeliveryReceipt{}
+	for _, attachment := range attachments {
+		archivePath := filepath.Join(periodDir, filepath.Base(attachment.Path))
+		if err := copyFile(attachment.Path, archivePath); err != nil {
+			return nil, err
+		}
+		receipt.Recipients = append(receipt.Recipients, archivePath)
+	}
+
+	return receipt, nil
+}
+
+// copyFile copies the file at src to dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening attachment: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("error creating archive file: %w", err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("error archiving attachment: %w", err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("error closing archive file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// Webhook event names sent in the generic payload
+const (
+	EventMonthlyReport = "monthly_report"
+	EventDailySummary  = "daily_summary"
+	EventReminder      = "reminder"
+	EventPolicyAlert   = "policy_alert"
+)
+
+// GenericWebhookService implements the NotificationService interface by posting JSON documents
+// to an arbitrary endpoint, optionally signed with an HMAC-SHA256 of the body
+type GenericWebhookService struct {
+	webhook *webhookClient
+}
+
+// WebhookPayload is the JSON document posted by the generic webhook service
+type WebhookPayload struct {
+	Event        string               `json:"event"`
+	Period       string               `json:"period"`
+	Date         string               `json:"date,omitempty"`
+	Owner        string               `json:"owner,omitempty"`
+	TotalMinutes int                  `json:"total_minutes"`
+	MonthMinutes int                  `json:"month_minutes,omitempty"`
+	Entries      []WebhookEntry       `json:"entries"`
+	Attachments  []string             `json:"attachments,omitempty"`
+	Violations   []WebhookViolation   `json:"violations,omitempty"`
+	Quarantined  []WebhookQuarantined `json:"quarantined,omitempty"`
+}
+
+// WebhookQuarantined is an invalid entry left out of the reports, in the generic webhook payload
+type WebhookQuarantined struct {
+	Source string `json:"source"`
+	Line   int    `json:"line,omitempty"`
+	Ticket string `json:"ticket_url,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// WebhookViolation is an overtime limit violation in the generic webhook payload
+type WebhookViolation struct {
+	Kind    string `json:"kind"` // daily or monthly
+	Owner   string `json:"owner,omitempty"`
+	Date    string `json:"date,omitempty"` // day of daily violations
+	Minutes int    `json:"minutes"`
+	Limit   int    `json:"limit"`
+}
+
+// WebhookEntry is a single overtime entry in the generic webhook payload
+type WebhookEntry struct {
+	TicketURL string `json:"ticket_url"`
+	Minutes   int    `json:"minutes"`
+	Date      string `json:"date"`
+	Owner     string `json:"owner,omitempty"`
+}
+
+// NewGenericWebhookService creates a new generic JSON webhook service.
+// Headers are added to every request and a non-empty secret enables payload signing.
+func NewGenericWebhookService(webhookURL string, headers map[string]string, secret string) repositories.NotificationService {
+	return &GenericWebhookService{
+		webhook: newWebhookClient(webhookURL, headers, secret),
+	}
+}
+
+// SendReportByEmail posts the monthly report document to the webhook
+func (s *GenericWebhookService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
+	payload := WebhookPayload{
+		Event:        EventMonthlyReport,
+		Period:       report.Period,
+		TotalMinutes: report.TotalTime,
+		Entries:      newWebhookEntries(report.Entries),
+	}
+	payload.Attachments = attachmentNames(attachments)
+
+	if err := s.webhook.postJSON(ctx, payload); err != nil {
+		return nil, fmt.Errorf("error sending webhook notification: %w", err)
+	}
+	return &entities.DeliveryReceipt{}, nil
+}
+
+// SendDailySummary posts the daily processing result to the webhook
+func (s *GenericWebhookService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	payload := WebhookPayload{
+		Event:        EventDailySummary,
+		Date:         summary.Date.Format("2006-01-02"),
+		TotalMinutes: summary.TotalMinutes(),
+		Entries:      newWebhookEntries(summary.Entries),
+	}
+	if summary.MonthReport != nil {
+		payload.Period = summary.MonthReport.Period
+		payload.MonthMinutes = summary.MonthReport.TotalTime
+	}
+	for _, entry := range summary.Quarantined {
+		payload.Quarantined = append(payload.Quarantined, WebhookQuarantined{
+			Source: entry.Source,
+			Line:   entry.Line,
+			Ticket: entry.Ticket,
+			Reason: entry.Reason,
+		})
+	}
+
+	if err := s.webhook.postJSON(ctx, payload); err != nil {
+		return fmt.Errorf("error sending webhook daily summary: %w", err)
+	}
+	return nil
+}
+
+// SendReminder posts a reminder for an owner without entries to the webhook
+func (s *GenericWebhookService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	payload := WebhookPayload{
+		Event:   EventReminder,
+		Date:    reminder.Date.Format("2006-01-02"),
+		Owner:   reminder.Owner,
+		Entries: []WebhookEntry{},
+	}
+
+	if err := s.webhook.postJSON(ctx, payload); err != nil {
+		return fmt.Errorf("error sending webhook reminder: %w", err)
+	}
+	return nil
+}
+
+// SendPolicyAlert posts the overtime limit violations to the webhook
+func (s *GenericWebhookService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	payload := WebhookPayload{
+		Event:   EventPolicyAlert,
+		Period:  alert.Period,
+		Owner:   alert.Manager,
+		Entries: []WebhookEntry{},
+	}
+	for _, violation := range alert.Violations {
+		item := WebhookViolation{
+			Kind:    string(violation.Kind),
+			Owner:   violation.Owner,
+			Minutes: violation.Minutes,
+			Limit:   violation.Limit,
+		}
+		if violation.Kind == entities.ViolationDaily {
+			item.Date = violation.Date.Format("2006-01-02")
+		}
+		payload.Violations = append(payload.Violations, item)
+	}
+
+	if err := s.webhook.postJSON(ctx, payload); err != nil {
+		return fmt.Errorf("error sending webhook policy alert: %w", err)
+	}
+	return nil
+}
+
+// newWebhookEntries converts overtime entries to their payload representation
+func newWebhookEntries(entries []entities.OvertimeEntry) []WebhookEntry {
+	result := make([]WebhookEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, WebhookEntry{
+			TicketURL: entry.TicketURL,
+			Minutes:   entry.Minutes,
+			Date:      entry.Date.Format("2006-01-02"),
+			Owner:     entry.Owner,
+		})
+	}
+	return result
+}
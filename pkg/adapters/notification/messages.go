@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// messageFormat holds the settings of notification message texts
+type messageFormat struct {
+	duration entities.DurationFormat
+}
+
+// MessageOption configures the texts of a notification service
+type MessageOption func(*messageFormat)
+
+// WithDurationFormat sets how durations are written in messages, minutes by default
+func WithDurationFormat(format entities.DurationFormat) MessageOption {
+	return func(m *messageFormat) {
+		m.duration = format
+	}
+}
+
+// newMessageFormat applies the options to the default message settings
+func newMessageFormat(options []MessageOption) messageFormat {
+	var m messageFormat
+	for _, option := range options {
+		option(&m)
+	}
+	m.duration = m.duration.OrDefault()
+	return m
+}
+
+// durationText writes minutes with their unit, e.g. "90 minutos" or "01:30 horas"
+func (m messageFormat) durationText(minutes int) string {
+	if m.duration.Unit == entities.DurationMinutes {
+		return fmt.Sprintf("%d minutos", minutes)
+	}
+	return m.duration.Format(minutes) + " horas"
+}
+
+// totalText writes the report total and entry count, with the billable total when minutes are rounded
+func (m messageFormat) totalText(report *entities.OvertimeReport) string {
+	text := fmt.Sprintf("%s em %d lançamentos", m.durationText(report.TotalTime), len(report.Entries))
+	if report.Rounding.Enabled() {
+		text += fmt.Sprintf(" (faturável: %s)", m.durationText(report.BillableTime))
+	}
+	return text
+}
+
+// monthlySummaryText builds the chat message for a monthly report
+func (m messageFormat) monthlySummaryText(report *entities.OvertimeReport, attachments []entities.Attachment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Relatório Mensal de Horas Extras - %s\n", report.Period)
+	fmt.Fprintf(&b, "Total: %s\n", m.totalText(report))
+	b.WriteString(m.ticketsText(report))
+	if len(report.Pending) > 0 {
+		fmt.Fprintf(&b, "Pendentes de aprovação: %s em %d lançamentos\n", m.durationText(report.PendingMinutes()), len(report.Pending))
+	}
+	if len(attachments) > 0 {
+		fmt.Fprintf(&b, "Arquivos: %s\n", strings.Join(attachmentNames(attachments), ", "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// ticketsText writes one line per ticket with its subtotal, most worked first
+func (m messageFormat) ticketsText(report *entities.OvertimeReport) string {
+	var b strings.Builder
+	for _, ticket := range report.ByTicket() {
+		entry := ticket.Entries[0]
+		entry.TicketURL = ticket.Label
+		fmt.Fprintf(&b, "• %s: %s", entryLabel(entry), m.durationText(ticket.Minutes))
+		if ticket.Count() > 1 {
+			fmt.Fprintf(&b, " em %d lançamentos", ticket.Count())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// dailySummaryText builds the chat message for a daily processing result
+func (m messageFormat) dailySummaryText(summary *entities.DailySummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Horas extras de %s\n", summary.Date.Format("2006-01-02"))
+	fmt.Fprintf(&b, "%d lançamentos, %s\n", len(summary.Entries), m.durationText(summary.TotalMinutes()))
+	for _, entry := range summary.Entries {
+		fmt.Fprintf(&b, "• %s: %s\n", entry.TicketURL, m.durationText(entry.Minutes))
+	}
+	if summary.MonthReport != nil {
+		fmt.Fprintf(&b, "Total do mês (%s): %s\n", summary.MonthReport.Period, m.durationText(summary.MonthReport.TotalTime))
+	}
+	if len(summary.Quarantined) > 0 {
+		fmt.Fprintf(&b, "Lançamentos em quarentena: %d\n", len(summary.Quarantined))
+		for _, entry := range summary.Quarantined {
+			fmt.Fprintf(&b, "• %s\n", entry)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// policyAlertText builds the message listing the overtime limit violations of a period
+func (m messageFormat) policyAlertText(alert *entities.PolicyAlert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alerta de Limite de Horas Extras - %s\n", alert.Period)
+	for _, violation := range alert.Violations {
+		owner := violation.Owner
+		if owner == "" {
+			owner = "sem responsável"
+		}
+		when := "no mês"
+		if violation.Kind == entities.ViolationDaily {
+			when = "em " + violation.Date.Format("02/01/2006")
+		}
+		fmt.Fprintf(&b, "• %s: %s %s (limite: %s)\n", owner, m.durationText(violation.Minutes), when, m.durationText(violation.Limit))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// entryLabel names the ticket of an entry, with its key and title when the report was enriched
+func entryLabel(entry entities.OvertimeEntry) string {
+	if label := entry.Ticket.Label(); label != "" {
+		return fmt.Sprintf("%s (%s)", label, entry.TicketURL)
+	}
+	return entry.TicketURL
+}
+
+// reminderText builds the message asking an owner to log a day of overtime
+func reminderText(reminder *entities.Reminder) string {
+	return fmt.Sprintf("Lembrete: %s estava de plantão em %s e não registrou horas extras.",
+		reminder.Owner, reminder.Date.Format("2006-01-02"))
+}
+
+// attachmentNames returns the file names of the attachments
+func attachmentNames(attachments []entities.Attachment) []string {
+	names := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		names = append(names, filepath.Base(attachment.Path))
+	}
+	return names
+}
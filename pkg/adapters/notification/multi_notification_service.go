@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// Channel is a named notification service with its failure policy
+type Channel struct {
+	Name     string
+	Service  repositories.NotificationService
+	Required bool
+}
+
+// ChannelResult records the outcome of a delivery to a single channel
+type ChannelResult struct {
+	Channel  string
+	Required bool
+	Err      error
+}
+
+// Delivered reports whether the channel accepted the notification
+func (r ChannelResult) Delivered() bool {
+	return r.Err == nil
+}
+
+// MultiNotificationService implements the NotificationService interface by fanning out
+// to several channels. Failures of optional channels are recorded but do not fail the delivery.
+type MultiNotificationService struct {
+	channels      []Channel
+	results       []ChannelResult
+	reportResults []ChannelResult
+}
+
+// NewMultiNotificationService creates a notification service delivering to all given channels
+func NewMultiNotificationService(channels ...Channel) *MultiNotificationService {
+	return &MultiNotificationService{
+		channels: channels,
+	}
+}
+
+// SendReportByEmail sends the report through every channel. It fails when a required
+// channel fails or when no channel at all accepted the report. The returned receipt
+// merges the receipts of all channels, with message IDs keyed by channel name.
+func (s *MultiNotificationService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
+	merged := &entities.DeliveryReceipt{MessageIDs: map[string]string{}}
+	err := s.deliver(func(channel Channel) (bool, error) {
+		receipt, err := channel.Service.SendReportByEmail(ctx, report, attachments)
+		if err != nil || receipt == nil {
+			return true, err
+		}
+
+		for key, id := range receipt.MessageIDs {
+			name := channel.Name
+			if len(receipt.MessageIDs) > 1 {
+				name += "." + key
+			}
+			merged.MessageIDs[name] = id
+		}
+		merged.Recipients = append(merged.Recipients, receipt.Recipients...)
+		return true, nil
+	})
+	s.reportResults = s.results
+	if err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// SendDailySummary sends the summary through every channel supporting daily summaries
+func (s *MultiNotificationService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	return s.deliver(func(channel Channel) (bool, error) {
+		notifier, ok := channel.Service.(repositories.DailySummaryNotifier)
+		if !ok {
+			return false, nil
+		}
+		return true, notifier.SendDailySummary(ctx, summary)
+	})
+}
+
+// SendReminder sends the reminder through every channel supporting reminders
+func (s *MultiNotificationService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	return s.deliver(func(channel Channel) (bool, error) {
+		notifier, ok := channel.Service.(repositories.ReminderNotifier)
+		if !ok {
+			return false, nil
+		}
+		return true, notifier.SendReminder(ctx, reminder)
+	})
+}
+
+// SendPolicyAlert sends the alert through every channel supporting policy alerts
+func (s *MultiNotificationService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	return s.deliver(func(channel Channel) (bool, error) {
+		notifier, ok := channel.Service.(repositories.PolicyAlertNotifier)
+		if !ok {
+			return false, nil
+		}
+		return true, notifier.SendPolicyAlert(ctx, alert)
+	})
+}
+
+// Results returns the per-channel outcome of the last delivery
+func (s *MultiNotificationService) Results() []ChannelResult {
+	return s.results
+}
+
+// ReportResults returns the per-channel outcome of the last report delivery, kept when
+// other notifications such as policy alerts are sent after the report
+func (s *MultiNotificationService) ReportResults() []ChannelResult {
+	return s.reportResults
+}
+
+// deliver runs send for every channel and aggregates the failures according to the channel policy.
+// send reports false when the channel does not support the notification.
+func (s *MultiNotificationService) deliver(send func(Channel) (bool, error)) error {
+	s.results = nil
+
+	var requiredErrs, optionalErrs []error
+	delivered := 0
+	for _, channel := range s.channels {
+		supported, err := send(channel)
+		if !supported {
+			continue
+		}
+
+		s.results = append(s.results, ChannelResult{
+			Channel:  channel.Name,
+			Required: channel.Required,
+			Err:      err,
+		})
+
+		switch {
+		case err == nil:
+			delivered++
+		case channel.Required:
+			requiredErrs = append(requiredErrs, fmt.Errorf("channel %s: %w", channel.Name, err))
+		default:
+			optionalErrs = append(optionalErrs, fmt.Errorf("channel %s: %w", channel.Name, err))
+		}
+	}
+
+	if len(requiredErrs) > 0 {
+		return fmt.Errorf("error delivering to required channels: %w", errors.Join(requiredErrs...))
+	}
+
+	// Optional failures only matter when nothing got through
+	if delivered == 0 && len(optionalErrs) > 0 {
+		return fmt.Errorf("error delivering to all channels: %w", errors.Join(optionalErrs...))
+	}
+
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"mime"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/MateSousa/overtime-script/pkg/domain/entities"
@@ -20,19 +22,21 @@ type SESEmailService struct {
 	senderEmail string
 	recipient   string
 	region      string
+	messages    messageFormat
 }
 
 // NewSESEmailService creates a new AWS SES email service
-func NewSESEmailService(senderEmail, recipient, region string) repositories.NotificationService {
+func NewSESEmailService(senderEmail, recipient, region string, options ...MessageOption) repositories.NotificationService {
 	return &SESEmailService{
 		senderEmail: senderEmail,
 		recipient:   recipient,
 		region:      region,
+		messages:    newMessageFormat(options),
 	}
 }
 
-// SendReportByEmail sends an overtime report via email with an attachment
-func (s *SESEmailService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachmentPath string) error {
+// SendReportByEmail sends an overtime report via email with one part per attachment
+func (s *SESEmailService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
 	// Build the email body with a simple message
 	emailBody := fmt.Sprintf(`Caros,
 
@@ -40,35 +44,23 @@ Espero que estejam bem!
 
 Segue em anexo as horas extra do mês de %s.
 
-Atenciosamente,`, report.Period)
+Total: %s.
 
-	// Read the file content
-	fileContent, err := os.ReadFile(attachmentPath)
-	if err != nil {
-		return fmt.Errorf("error reading file: %w", err)
-	}
+Por ticket:
+%s
+Atenciosamente,`, report.Period, s.messages.totalText(report), s.messages.ticketsText(report))
 
 	// Create a new AWS session
 	sess, err := session.NewSession(&aws.Config{
 		Region: aws.String(s.region),
 	})
 	if err != nil {
-		return fmt.Errorf("error creating AWS session: %w", err)
+		return nil, fmt.Errorf("error creating AWS session: %w", err)
 	}
 
 	// Create multipart message boundary
 	boundary := "==Multipart_Boundary_x" + time.Now().Format("20060102150405") + "x"
 
-	// Determine content type based on file extension
-	fileExt := filepath.Ext(attachmentPath)
-	contentType := "application/octet-stream" // Default content type
-	
-	if fileExt == ".csv" {
-		contentType = "text/csv"
-	} else if fileExt == ".xlsx" {
-		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	}
-
 	// Create the raw message
 	rawMessage := fmt.Sprintf("From: %s\n", s.senderEmail) +
 		fmt.Sprintf("To: %s\n", s.recipient) +
@@ -81,15 +73,17 @@ Atenciosamente,`, report.Period)
 		"Content-Transfer-Encoding: 7bit\n" +
 		"\n" +
 		emailBody + "\n" +
-		"\n" +
-		fmt.Sprintf("--%s\n", boundary) +
-		fmt.Sprintf("Content-Type: %s; charset=UTF-8\n", contentType) +
-		"Content-Transfer-Encoding: base64\n" +
-		fmt.Sprintf("Content-Disposition: attachment; filename=\"%s\"\n", filepath.Base(attachmentPath)) +
-		"\n" +
-		base64.StdEncoding.EncodeToString(fileContent) + "\n" +
-		"\n" +
-		fmt.Sprintf("--%s--", boundary)
+		"\n"
+
+	// Add one part per attachment
+	for _, attachment := range attachments {
+		part, err := attachmentPart(boundary, attachment)
+		if err != nil {
+			return nil, err
+		}
+		rawMessage += part
+	}
+	rawMessage += fmt.Sprintf("--%s--", boundary)
 
 	// Create an SES client
 	svc := ses.New(sess)
@@ -104,10 +98,115 @@ Atenciosamente,`, report.Period)
 	}
 
 	// Send the email
-	_, err = svc.SendRawEmail(input)
+	output, err := svc.SendRawEmailWithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("error sending email: %w", err)
+	}
+
+	return &entities.DeliveryReceipt{
+		MessageIDs: map[string]string{"ses": aws.StringValue(output.MessageId)},
+		Recipients: []string{s.recipient},
+	}, nil
+}
+
+// attachmentPart builds the base64 encoded MIME part of an attachment
+func attachmentPart(boundary string, attachment entities.Attachment) (string, error) {
+	// Read the file content
+	fileContent, err := os.ReadFile(attachment.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+
+	return fmt.Sprintf("--%s\n", boundary) +
+		fmt.Sprintf("Content-Type: %s\n", attachmentContentType(attachment)) +
+		"Content-Transfer-Encoding: base64\n" +
+		fmt.Sprintf("Content-Disposition: attachment; filename=\"%s\"\n", filepath.Base(attachment.Path)) +
+		"\n" +
+		base64.StdEncoding.EncodeToString(fileContent) + "\n" +
+		"\n", nil
+}
+
+// attachmentContentType returns the content type of the export format of an attachment,
+// guessed from the file extension when unknown. Only text formats are given a charset.
+func attachmentContentType(attachment entities.Attachment) string {
+	contentType := attachment.MIMEType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(attachment.Path))
+	}
+	// Drop parameters such as charset, which is added below for text formats
+	contentType, _, _ = strings.Cut(contentType, ";")
+	if contentType == "" {
+		return "application/octet-stream"
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		contentType += "; charset=UTF-8"
+	}
+	return contentType
+}
+
+// SendDailySummary sends the daily digest with the entries merged on the day and the month-to-date total
+func (s *SESEmailService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	subject := fmt.Sprintf("Darede - Resumo Diário de Horas Extras - %s", summary.Date.Format("02/01/2006"))
+	if err := s.sendTextEmail(ctx, s.recipient, subject, s.messages.dailySummaryText(summary)); err != nil {
+		return fmt.Errorf("error sending daily summary email: %w", err)
+	}
+	return nil
+}
+
+// SendReminder emails the owner, who is expected to be identified by an email address
+func (s *SESEmailService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	subject := fmt.Sprintf("Darede - Lembrete de Horas Extras - %s", reminder.Date.Format("02/01/2006"))
+	if err := s.sendTextEmail(ctx, reminder.Owner, subject, reminderText(reminder)); err != nil {
+		return fmt.Errorf("error sending reminder email: %w", err)
+	}
+	return nil
+}
+
+// SendPolicyAlert emails the overtime limit violations to the manager, or to the report recipient
+func (s *SESEmailService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	recipient := alert.Manager
+	if recipient == "" {
+		recipient = s.recipient
+	}
+	subject := fmt.Sprintf("Darede - Alerta de Limite de Horas Extras - %s", alert.Period)
+	if err := s.sendTextEmail(ctx, recipient, subject, s.messages.policyAlertText(alert)); err != nil {
+		return fmt.Errorf("error sending policy alert email: %w", err)
+	}
+	return nil
+}
+
+// sendTextEmail sends a plain text email without attachments
+func (s *SESEmailService) sendTextEmail(ctx context.Context, recipient, subject, body string) error {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(s.region),
+	})
 	if err != nil {
+		return fmt.Errorf("error creating AWS session: %w", err)
+	}
+
+	svc := ses.New(sess)
+	input := &ses.SendEmailInput{
+		Destination: &ses.Destination{
+			ToAddresses: []*string{aws.String(recipient)},
+		},
+		Message: &ses.Message{
+			Subject: &ses.Content{
+				Charset: aws.String("UTF-8"),
+				Data:    aws.String(subject),
+			},
+			Body: &ses.Body{
+				Text: &ses.Content{
+					Charset: aws.String("UTF-8"),
+					Data:    aws.String(body),
+				},
+			},
+		},
+		Source: aws.String(s.senderEmail),
+	}
+
+	if _, err := svc.SendEmailWithContext(ctx, input); err != nil {
 		return fmt.Errorf("error sending email: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// SlackWebhookService implements the NotificationService interface using Slack incoming webhooks
+type SlackWebhookService struct {
+	webhook  *webhookClient
+	messages messageFormat
+}
+
+// slackMessage is the payload accepted by Slack incoming webhooks
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// NewSlackWebhookService creates a new Slack incoming webhook service
+func NewSlackWebhookService(webhookURL string, options ...MessageOption) repositories.NotificationService {
+	return &SlackWebhookService{
+		webhook:  newWebhookClient(webhookURL, nil, ""),
+		messages: newMessageFormat(options),
+	}
+}
+
+// SendReportByEmail posts the monthly report summary to the Slack channel.
+// Incoming webhooks cannot carry files, so only the attachment names are mentioned.
+func (s *SlackWebhookService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
+	if err := s.webhook.postJSON(ctx, slackMessage{Text: s.messages.monthlySummaryText(report, attachments)}); err != nil {
+		return nil, fmt.Errorf("error sending Slack notification: %w", err)
+	}
+	return &entities.DeliveryReceipt{}, nil
+}
+
+// SendDailySummary posts the daily processing result to the Slack channel
+func (s *SlackWebhookService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	if err := s.webhook.postJSON(ctx, slackMessage{Text: s.messages.dailySummaryText(summary)}); err != nil {
+		return fmt.Errorf("error sending Slack daily summary: %w", err)
+	}
+	return nil
+}
+
+// SendReminder posts a reminder mentioning the owner to the Slack channel
+func (s *SlackWebhookService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	if err := s.webhook.postJSON(ctx, slackMessage{Text: reminderText(reminder)}); err != nil {
+		return fmt.Errorf("error sending Slack reminder: %w", err)
+	}
+	return nil
+}
+
+// SendPolicyAlert posts the overtime limit violations to the Slack channel
+func (s *SlackWebhookService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	if err := s.webhook.postJSON(ctx, slackMessage{Text: s.messages.policyAlertText(alert)}); err != nil {
+		return fmt.Errorf("error sending Slack policy alert: %w", err)
+	}
+	return nil
+}
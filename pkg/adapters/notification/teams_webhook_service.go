@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// TeamsWebhookService implements the NotificationService interface using Microsoft Teams incoming webhooks
+type TeamsWebhookService struct {
+	webhook  *webhookClient
+	messages messageFormat
+}
+
+// teamsMessageCard is the legacy connector card accepted by Teams incoming webhooks
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	Summary    string `json:"summary"`
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+	ThemeColor string `json:"themeColor,omitempty"`
+}
+
+// NewTeamsWebhookService creates a new Microsoft Teams incoming webhook service
+func NewTeamsWebhookService(webhookURL string, options ...MessageOption) repositories.NotificationService {
+	return &TeamsWebhookService{
+		webhook:  newWebhookClient(webhookURL, nil, ""),
+		messages: newMessageFormat(options),
+	}
+}
+
+// SendReportByEmail posts the monthly report summary to the Teams channel
+func (s *TeamsWebhookService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
+	card := newTeamsMessageCard(s.messages.monthlySummaryText(report, attachments))
+	if err := s.webhook.postJSON(ctx, card); err != nil {
+		return nil, fmt.Errorf("error sending Teams notification: %w", err)
+	}
+	return &entities.DeliveryReceipt{}, nil
+}
+
+// SendDailySummary posts the daily processing result to the Teams channel
+func (s *TeamsWebhookService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	card := newTeamsMessageCard(s.messages.dailySummaryText(summary))
+	if err := s.webhook.postJSON(ctx, card); err != nil {
+		return fmt.Errorf("error sending Teams daily summary: %w", err)
+	}
+	return nil
+}
+
+// newTeamsMessageCard uses the first line of the text as the card title
+func newTeamsMessageCard(text string) teamsMessageCard {
+	title, body, _ := strings.Cut(text, "\n")
+
+	// Teams renders markdown, which needs blank lines to break paragraphs
+	body = strings.ReplaceAll(body, "\n", "\n\n")
+
+	return teamsMessageCard{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		Summary:    title,
+		Title:      title,
+		Text:       body,
+		ThemeColor: "4472C4",
+	}
+}
+
+// SendReminder posts a reminder mentioning the owner to the Teams channel
+func (s *TeamsWebhookService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	if err := s.webhook.postJSON(ctx, newTeamsMessageCard(reminderText(reminder))); err != nil {
+		return fmt.Errorf("error sending Teams reminder: %w", err)
+	}
+	return nil
+}
+
+// SendPolicyAlert posts the overtime limit violations to the Teams channel
+func (s *TeamsWebhookService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	if err := s.webhook.postJSON(ctx, newTeamsMessageCard(s.messages.policyAlertText(alert))); err != nil {
+		return fmt.Errorf("error sending Teams policy alert: %w", err)
+	}
+	return nil
+}
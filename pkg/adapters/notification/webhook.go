@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of webhook payloads
+const SignatureHeader = "X-Overtime-Signature"
+
+// defaultWebhookTimeout bounds every webhook request
+const defaultWebhookTimeout = 10 * time.Second
+
+// webhookClient posts JSON payloads to an HTTP endpoint
+type webhookClient struct {
+	url     string
+	headers map[string]string
+	secret  string
+	client  *http.Client
+}
+
+// newWebhookClient creates a webhook client with the default timeout
+func newWebhookClient(url string, headers map[string]string, secret string) *webhookClient {
+	return &webhookClient{
+		url:     url,
+		headers: headers,
+		secret:  secret,
+		client:  &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+// postJSON encodes the payload as JSON and posts it to the webhook URL
+func (c *webhookClient) postJSON(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	// Sign the body so receivers can verify the sender
+	if c.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+SignPayload(c.secret, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// SignPayload computes the hex encoded HMAC-SHA256 of a payload
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// ConfigMap data keys holding one line per overtime entry. Only ticket_url and minutes
+// are mandatory; a single line in an optional key applies to every entry of the ConfigMap.
+const (
+	keyTicketURL   = "ticket_url"
+	keyMinutes     = "minutes"
+	keyOwner       = "owner"
+	keyMultiplier  = "multiplier"
+	keyDate        = "date"
+	keyDescription = "description"
+	keySource      = "source"      // ConfigMap the entry was logged in, stored in merged reports
+	keyExternalID  = "external_id" // identifier of imported entries in their origin
+)
+
+// entryDateLayout is the layout of the entry dates stored in ConfigMaps
+const entryDateLayout = "2006-01-02"
+
+// decodeEntries parses the overtime entries stored in ConfigMap data. Entries without a
+// stored date are dated with the given date. Lines that cannot be parsed are returned as
+// quarantined, as is the whole ConfigMap when the mandatory keys are missing; the caller
+// sets the source of both.
+func decodeEntries(data map[string]string, date time.Time) ([]entities.OvertimeEntry, []entities.QuarantinedEntry) {
+	tickets, ticketsOk := data[keyTicketURL]
+	minutes, minutesOk := data[keyMinutes]
+	if !ticketsOk || !minutesOk {
+		return nil, []entities.QuarantinedEntry{{Reason: fmt.Sprintf("missing %s or %s key", keyTicketURL, keyMinutes)}}
+	}
+
+	ticketList := strings.Split(tickets, "\n")
+	minutesList := strings.Split(minutes, "\n")
+	owners := strings.Split(data[keyOwner], "\n")
+	multipliers := strings.Split(data[keyMultiplier], "\n")
+	dates := strings.Split(data[keyDate], "\n")
+	descriptions := strings.Split(data[keyDescription], "\n")
+	sources := strings.Split(data[keySource], "\n")
+	externalIDs := strings.Split(data[keyExternalID], "\n")
+
+	// Tickets and minutes are paired by line; a line missing either is quarantined
+	count := max(len(ticketList), len(minutesList))
+
+	var entries []entities.OvertimeEntry
+	var quarantined []entities.QuarantinedEntry
+	for i := 0; i < count; i++ {
+		ticket := strings.TrimSpace(lineOf(ticketList, i))
+		minutesText := strings.TrimSpace(lineOf(minutesList, i))
+		if ticket == "" && minutesText == "" {
+			continue
+		}
+		reject := func(reason string, args ...interface{}) {
+			quarantined = append(quarantined, entities.QuarantinedEntry{Line: i + 1, Ticket: ticket, Reason: fmt.Sprintf(reason, args...)})
+		}
+
+		if minutesText == "" {
+			reject("missing minutes")
+			continue
+		}
+		minuteVal, err := strconv.Atoi(minutesText)
+		if err != nil {
+			reject("minutes %q is not a number", minutesText)
+			continue
+		}
+
+		var multiplier float64
+		if text := lineAt(multipliers, i); text != "" {
+			if multiplier, err = strconv.ParseFloat(text, 64); err != nil {
+				reject("multiplier %q is not a number", text)
+				continue
+			}
+		}
+
+		entryDate := date
+		if text := lineAt(dates, i); text != "" {
+			if entryDate, err = time.ParseInLocation(entryDateLayout, text, date.Location()); err != nil {
+				reject("date %q is not formatted as YYYY-MM-DD", text)
+				continue
+			}
+		}
+
+		entries = append(entries, entities.OvertimeEntry{
+			TicketURL:   ticket,
+			Minutes:     minuteVal,
+			Date:        entryDate,
+			Owner:       lineAt(owners, i),
+			Multiplier:  multiplier,
+			Description: lineAt(descriptions, i),
+			Source:      lineAt(sources, i),
+			Line:        i + 1,
+			ExternalID:  lineOf(externalIDs, i),
+		})
+	}
+
+	return entries, quarantined
+}
+
+// encodeEntries stores overtime entries as ConfigMap data with one line per entry
+func encodeEntries(entries []entities.OvertimeEntry) map[string]string {
+	var tickets, minutes, owners, multipliers, dates, descriptions, sources, externalIDs []string
+	imported := false
+	for _, entry := range entries {
+		tickets = append(tickets, entry.TicketURL)
+		minutes = append(minutes, strconv.Itoa(entry.Minutes))
+		owners = append(owners, entry.Owner)
+		multiplier := ""
+		if entry.Multiplier != 0 {
+			multiplier = strconv.FormatFloat(entry.Multiplier, 'f', -1, 64)
+		}
+		multipliers = append(multipliers, multiplier)
+		dates = append(dates, entry.Date.Format(entryDateLayout))
+		// Values are stored one per line, so descriptions must stay on a single line
+		descriptions = append(descriptions, strings.Join(strings.Fields(entry.Description), " "))
+		sources = append(sources, entry.Source)
+		externalIDs = append(externalIDs, entry.ExternalID)
+		imported = imported || entry.ExternalID != ""
+	}
+
+	data := map[string]string{
+		keyTicketURL:   strings.Join(tickets, "\n"),
+		keyMinutes:     strings.Join(minutes, "\n"),
+		keyOwner:       strings.Join(owners, "\n"),
+		keyMultiplier:  strings.Join(multipliers, "\n"),
+		keyDate:        strings.Join(dates, "\n"),
+		keyDescription: strings.Join(descriptions, "\n"),
+		keySource:      strings.Join(sources, "\n"),
+	}
+	if imported {
+		data[keyExternalID] = strings.Join(externalIDs, "\n")
+	}
+	return data
+}
+
+// lineOf returns the i-th line, or an empty string past the last line
+func lineOf(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// lineAt returns the trimmed i-th line, or the only line when a single value applies to every entry
+func lineAt(lines []string, i int) string {
+	if len(lines) == 1 {
+		return strings.TrimSpace(lines[0])
+	}
+	if i < len(lines) {
+		return strings.TrimSpace(lines[i])
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// FileTemplateRepository implements the TemplateRepository interface reading a template from disk
+type FileTemplateRepository struct {
+	path string
+}
+
+// NewFileTemplateRepository creates a new template repository reading the given file
+func NewFileTemplateRepository(path string) *FileTemplateRepository {
+	return &FileTemplateRepository{
+		path: path,
+	}
+}
+
+// GetTemplate reads the template file
+func (r *FileTemplateRepository) GetTemplate(ctx context.Context) ([]byte, error) {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading template %s: %w", r.path, err)
+	}
+	return content, nil
+}
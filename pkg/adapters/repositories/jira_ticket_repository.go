@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	domainrepos "github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// defaultJiraTimeout bounds every Jira request
+const defaultJiraTimeout = 10 * time.Second
+
+// JiraOptions configures the Jira REST API client
+type JiraOptions struct {
+	BaseURL string // e.g. https://acme.atlassian.net
+	// Email and API token authenticate with basic auth on Jira Cloud; without an email the
+	// token is sent as a bearer personal access token, as Jira Data Center expects
+	Email string
+	Token string
+	// ClientField is the issue field naming the client, e.g. customfield_10050. The project
+	// category is used when empty.
+	ClientField string
+}
+
+// JiraTicketRepository implements the TicketRepository interface using the Jira REST API
+type JiraTicketRepository struct {
+	options JiraOptions
+	client  *http.Client
+}
+
+// NewJiraTicketRepository creates a new Jira ticket repository
+func NewJiraTicketRepository(options JiraOptions) *JiraTicketRepository {
+	options.BaseURL = strings.TrimSuffix(options.BaseURL, "/")
+	return &JiraTicketRepository{
+		options: options,
+		client:  &http.Client{Timeout: defaultJiraTimeout},
+	}
+}
+
+// jiraIssue is the part of the Jira issue document read by the repository
+type jiraIssue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// jiraProject is the project field of a Jira issue
+type jiraProject struct {
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	ProjectCategory *struct {
+		Name string `json:"name"`
+	} `json:"projectCategory"`
+}
+
+// GetTicket fetches the summary, project and client of an issue
+func (r *JiraTicketRepository) GetTicket(ctx context.Context, key string) (*entities.TicketInfo, error) {
+	fields := []string{"summary", "project"}
+	if r.options.ClientField != "" {
+		fields = append(fields, r.options.ClientField)
+	}
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=%s", r.options.BaseURL, url.PathEscape(key), strings.Join(fields, ","))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Jira request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.options.Email != "" {
+		req.SetBasicAuth(r.options.Email, r.options.Token)
+	} else if r.options.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.options.Token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting Jira issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: jira issue %s", domainrepos.ErrTicketNotFound, key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("jira returned status %d for issue %s: %s", resp.StatusCode, key, strings.TrimSpace(string(body)))
+	}
+
+	var issue jiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("error decoding Jira issue %s: %w", key, err)
+	}
+
+	info := &entities.TicketInfo{Key: issue.Key}
+	if info.Key == "" {
+		info.Key = key
+	}
+	if summary, ok := issue.Fields["summary"]; ok {
+		json.Unmarshal(summary, &info.Title)
+	}
+
+	var project jiraProject
+	if err := json.Unmarshal(issue.Fields["project"], &project); err == nil {
+		info.Project = project.Name
+		if info.Project == "" {
+			info.Project = project.Key
+		}
+		if project.ProjectCategory != nil {
+			info.Client = project.ProjectCategory.Name
+		}
+	}
+	if client := jiraFieldText(issue.Fields[r.options.ClientField]); client != "" {
+		info.Client = client
+	}
+
+	return info, nil
+}
+
+// jiraFieldText reads a text, select or named object field, or "" for other values
+func jiraFieldText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var option struct {
+		Value string `json:"value"`
+		Name  string `json:"name"`
+	}
+	if err := json.Unmarshal(raw, &option); err == nil {
+		if option.Value != "" {
+			return option.Value
+		}
+		return option.Name
+	}
+	return ""
+}
+
+// CachedTicketRepository remembers the tickets fetched from another repository, and the
+// tickets it does not have, so each ticket is requested once however many entries reference
+// it. Other failures such as timeouts or server errors are retried on the next request.
+type CachedTicketRepository struct {
+	tickets domainrepos.TicketRepository
+	mu      sync.Mutex
+	cache   map[string]cachedTicket
+}
+
+// cachedTicket is a cached lookup result
+type cachedTicket struct {
+	info *entities.TicketInfo
+	err  error
+}
+
+// NewCachedTicketRepository wraps a ticket repository with an in-memory cache
+func NewCachedTicketRepository(tickets domainrepos.TicketRepository) *CachedTicketRepository {
+	return &CachedTicketRepository{
+		tickets: tickets,
+		cache:   make(map[string]cachedTicket),
+	}
+}
+
+// GetTicket returns the cached ticket, fetching it on the first request
+func (r *CachedTicketRepository) GetTicket(ctx context.Context, key string) (*entities.TicketInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.cache[key]; ok {
+		return cached.info, cached.err
+	}
+	info, err := r.tickets.GetTicket(ctx, key)
+	if err == nil || errors.Is(err, domainrepos.ErrTicketNotFound) {
+		r.cache[key] = cachedTicket{info: info, err: err}
+	}
+	return info, err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KubernetesOnCallRepository implements the OnCallRepository interface using a ConfigMap
+// whose keys are days (YYYY-MM-DD) and values the owners on-call that day, one per line
+type KubernetesOnCallRepository struct {
+	client    *kubernetes.Clientset
+	namespace string
+	name      string
+}
+
+// NewKubernetesOnCallRepository creates a new on-call schedule repository reading the named ConfigMap
+func NewKubernetesOnCallRepository(client *kubernetes.Clientset, namespace, name string) *KubernetesOnCallRepository {
+	return &KubernetesOnCallRepository{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// GetOnCallOwners returns the owners that were on-call on the given day
+func (r *KubernetesOnCallRepository) GetOnCallOwners(ctx context.Context, day time.Time) ([]string, error) {
+	cm, err := r.client.CoreV1().ConfigMaps(r.namespace).Get(ctx, r.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting on-call ConfigMap %s: %w", r.name, err)
+	}
+
+	var owners []string
+	for _, owner := range strings.Split(cm.Data[day.Format("2006-01-02")], "\n") {
+		owner = strings.TrimSpace(owner)
+		if owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+
+	return owners, nil
+}
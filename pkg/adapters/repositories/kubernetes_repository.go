@@ -2,18 +2,36 @@ package repositories
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/MateSousa/overtime-script/pkg/domain/entities"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
-// KubernetesOvertimeRepository implements the OvertimeRepository interface using Kubernetes ConfigMaps
+// Label and annotations recording the approval of the entries of a ConfigMap. ConfigMaps
+// without the status label are pending.
+const (
+	labelStatus         = "overtime/status"
+	annotationApprover  = "overtime/approver"
+	annotationReason    = "overtime/reason"
+	annotationDecidedAt = "overtime/decided-at"
+)
+
+// Label and annotation marking the ConfigMaps with quarantined entries, and why
+const (
+	labelQuarantined     = "overtime/quarantined"
+	annotationQuarantine = "overtime/quarantine"
+)
+
+// KubernetesOvertimeRepository implements the OvertimeRepository and ApprovalRepository
+// interfaces using Kubernetes ConfigMaps
 type KubernetesOvertimeRepository struct {
 	client    *kubernetes.Clientset
 	namespace string
@@ -27,72 +45,45 @@ func NewKubernetesOvertimeRepository(client *kubernetes.Clientset, namespace str
 	}
 }
 
-// GetOvertimeEntriesForPeriod fetches overtime entries for a specific time period from ConfigMaps
-func (r *KubernetesOvertimeRepository) GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, error) {
+// GetOvertimeEntriesForPeriod fetches overtime entries for a specific time period from ConfigMaps,
+// along with the lines that could not be parsed
+func (r *KubernetesOvertimeRepository) GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, []entities.QuarantinedEntry, error) {
 	// List ConfigMaps with label selector "app=overtime"
 	list, err := r.client.CoreV1().ConfigMaps(r.namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: "app=overtime",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error listing ConfigMaps: %w", err)
+		return nil, nil, fmt.Errorf("error listing ConfigMaps: %w", err)
 	}
 
 	var entries []entities.OvertimeEntry
+	var quarantined []entities.QuarantinedEntry
 	for _, cm := range list.Items {
 		// Check if the ConfigMap was created in the requested time period
 		if (cm.CreationTimestamp.Time.After(start) || cm.CreationTimestamp.Time.Equal(start)) && 
 		   (cm.CreationTimestamp.Time.Before(end) || cm.CreationTimestamp.Time.Equal(end)) {
 			
-			tickets, ticketsOk := cm.Data["ticket_url"]
-			minutes, minutesOk := cm.Data["minutes"]
-			
-			if !ticketsOk || !minutesOk {
-				continue
+			cmEntries, rejected := decodeEntries(cm.Data, cm.CreationTimestamp.Time)
+			approval := approvalOf(&cm)
+			for i := range cmEntries {
+				cmEntries[i].Source = cm.Name
+				cmEntries[i].Approval = approval
 			}
-			
-			ticketList := strings.Split(tickets, "\n")
-			minutesList := strings.Split(minutes, "\n")
-			
-			// Use the smaller length if counts differ
-			count := len(ticketList)
-			if len(minutesList) < count {
-				count = len(minutesList)
-			}
-			
-			for i := 0; i < count; i++ {
-				ticket := strings.TrimSpace(ticketList[i])
-				minuteStr := strings.TrimSpace(minutesList[i])
-				minuteVal, err := strconv.Atoi(minuteStr)
-				if err != nil {
-					minuteVal = 0
-				}
-				
-				entry := entities.OvertimeEntry{
-					TicketURL: ticket,
-					Minutes:   minuteVal,
-					Date:      cm.CreationTimestamp.Time,
-				}
-				entries = append(entries, entry)
+			for i := range rejected {
+				rejected[i].Source = cm.Name
 			}
+			entries = append(entries, cmEntries...)
+			quarantined = append(quarantined, rejected...)
 		}
 	}
 
-	return entries, nil
+	return entries, quarantined, nil
 }
 
 // SaveOvertimeReport saves an overtime report as a ConfigMap
 func (r *KubernetesOvertimeRepository) SaveOvertimeReport(ctx context.Context, report *entities.OvertimeReport) error {
 	// Create data map for ConfigMap
-	data := make(map[string]string)
-	
-	var ticketURLs, minutes []string
-	for _, entry := range report.Entries {
-		ticketURLs = append(ticketURLs, entry.TicketURL)
-		minutes = append(minutes, strconv.Itoa(entry.Minutes))
-	}
-	
-	data["ticket_url"] = strings.Join(ticketURLs, "\n")
-	data["minutes"] = strings.Join(minutes, "\n")
+	data := encodeEntries(report.Entries)
 	
 	// ConfigMap name (lowercase for RFC1123 compliance)
 	cmName := strings.ToLower(report.Period + "-overtime-merged")
@@ -132,31 +123,11 @@ func (r *KubernetesOvertimeRepository) GetMergedReport(ctx context.Context, mont
 	
 	report := entities.NewOvertimeReport(month)
 	
-	tickets, ticketsOk := cm.Data["ticket_url"]
-	minutes, minutesOk := cm.Data["minutes"]
+	// Merged data is written by SaveOvertimeReport from validated entries
+	entries, _ := decodeEntries(cm.Data, time.Now())
 	
-	if !ticketsOk || !minutesOk {
-		return report, nil // Return empty report if no data
-	}
-	
-	ticketList := strings.Split(tickets, "\n")
-	minutesList := strings.Split(minutes, "\n")
-	
-	// Use the smaller length if counts differ
-	count := len(ticketList)
-	if len(minutesList) < count {
-		count = len(minutesList)
-	}
-	
-	for i := 0; i < count; i++ {
-		ticket := strings.TrimSpace(ticketList[i])
-		minuteStr := strings.TrimSpace(minutesList[i])
-		minuteVal, err := strconv.Atoi(minuteStr)
-		if err != nil {
-			minuteVal = 0
-		}
-		
-		report.AddEntry(ticket, minuteVal)
+	for _, entry := range entries {
+		report.AppendEntry(entry)
 	}
 	
 	return report, nil
@@ -173,8 +144,250 @@ func (r *KubernetesOvertimeRepository) MergeOvertimeEntries(ctx context.Context,
 	
 	// Add all new entries to the existing report
 	for _, entry := range entries {
-		existingReport.AddEntry(entry.TicketURL, entry.Minutes)
+		existingReport.AppendEntry(entry)
 	}
 	
 	return existingReport, nil
-}
\ No newline at end of file
+}
+
+// GetDeliveryRecord retrieves the delivery record of a period, or nil if its report was never delivered
+func (r *KubernetesOvertimeRepository) GetDeliveryRecord(ctx context.Context, period string) (*entities.DeliveryRecord, error) {
+	cmName := deliveryConfigMapName(period)
+
+	cm, err := r.client.CoreV1().ConfigMaps(r.namespace).Get(ctx, cmName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting delivery ConfigMap %s: %w", cmName, err)
+	}
+
+	sentAt, err := time.Parse(time.RFC3339, cm.Data["sent_at"])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sent_at of delivery ConfigMap %s: %w", cmName, err)
+	}
+
+	record := &entities.DeliveryRecord{
+		Period:              period,
+		SentAt:              sentAt,
+		MessageIDs:          parsePairs(cm.Data["message_ids"]),
+		Recipients:          splitLines(cm.Data["recipients"]),
+		AttachmentChecksums: parsePairs(cm.Data["attachment_checksums"]),
+	}
+
+	return record, nil
+}
+
+// SaveDeliveryRecord persists the delivery record of a period as a ConfigMap
+func (r *KubernetesOvertimeRepository) SaveDeliveryRecord(ctx context.Context, record *entities.DeliveryRecord) error {
+	data := map[string]string{
+		"period":               record.Period,
+		"sent_at":              record.SentAt.UTC().Format(time.RFC3339),
+		"message_ids":          formatPairs(record.MessageIDs),
+		"recipients":           strings.Join(record.Recipients, "\n"),
+		"attachment_checksums": formatPairs(record.AttachmentChecksums),
+	}
+
+	cmName := deliveryConfigMapName(record.Period)
+	cmInterface := r.client.CoreV1().ConfigMaps(r.namespace)
+
+	existing, err := cmInterface.Get(ctx, cmName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		newCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: cmName,
+			},
+			Data: data,
+		}
+		_, err = cmInterface.Create(ctx, newCM, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error getting delivery ConfigMap %s: %w", cmName, err)
+	}
+
+	// Resending a period replaces its previous record
+	existing.Data = data
+	_, err = cmInterface.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// GetApprovals returns the approval of every overtime entry ConfigMap, by name
+func (r *KubernetesOvertimeRepository) GetApprovals(ctx context.Context) (map[string]entities.Approval, error) {
+	list, err := r.client.CoreV1().ConfigMaps(r.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=overtime",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ConfigMaps: %w", err)
+	}
+
+	approvals := make(map[string]entities.Approval, len(list.Items))
+	for i := range list.Items {
+		approvals[list.Items[i].Name] = approvalOf(&list.Items[i])
+	}
+	return approvals, nil
+}
+
+// SetApproval records a decision on the entries of a ConfigMap in its labels and annotations
+func (r *KubernetesOvertimeRepository) SetApproval(ctx context.Context, source string, approval entities.Approval) error {
+	cmInterface := r.client.CoreV1().ConfigMaps(r.namespace)
+	cm, err := cmInterface.Get(ctx, source, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting ConfigMap %s: %w", source, err)
+	}
+	if cm.Labels["app"] != "overtime" {
+		return fmt.Errorf("ConfigMap %s does not hold overtime entries", source)
+	}
+
+	cm.Labels[labelStatus] = string(approval.Status)
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
+	cm.Annotations[annotationApprover] = approval.Approver
+	cm.Annotations[annotationReason] = approval.Reason
+	cm.Annotations[annotationDecidedAt] = approval.DecidedAt.UTC().Format(time.RFC3339)
+
+	if _, err := cmInterface.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating ConfigMap %s: %w", source, err)
+	}
+	return nil
+}
+
+// SaveOvertimeEntries creates an entry ConfigMap, as create-cm.sh does, named after a hash of
+// its entries so that saving the same entries twice fails instead of logging them twice
+func (r *KubernetesOvertimeRepository) SaveOvertimeEntries(ctx context.Context, entries []entities.OvertimeEntry) (string, error) {
+	data := encodeEntries(entries)
+	delete(data, keySource)
+
+	hash := sha256.New()
+	for _, key := range []string{keyTicketURL, keyMinutes, keyOwner, keyMultiplier, keyDate, keyDescription, keyExternalID} {
+		fmt.Fprintf(hash, "%s=%s\n", key, data[key])
+	}
+	name := fmt.Sprintf("overtime-import-%x", hash.Sum(nil)[:6])
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app":     "overtime",
+				"created": time.Now().Format(entryDateLayout),
+			},
+		},
+		Data: data,
+	}
+	if _, err := r.client.CoreV1().ConfigMaps(r.namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("entries already saved in ConfigMap %s", name)
+		}
+		return "", fmt.Errorf("error creating ConfigMap %s: %w", name, err)
+	}
+	return name, nil
+}
+
+// GetImportedIDs reads the external IDs of the entries stored in entry ConfigMaps
+func (r *KubernetesOvertimeRepository) GetImportedIDs(ctx context.Context) (map[string]string, error) {
+	list, err := r.client.CoreV1().ConfigMaps(r.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=overtime",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ConfigMaps: %w", err)
+	}
+
+	ids := make(map[string]string)
+	for _, cm := range list.Items {
+		for _, id := range strings.Split(cm.Data[keyExternalID], "\n") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids[id] = cm.Name
+			}
+		}
+	}
+	return ids, nil
+}
+
+// QuarantineEntries records in each source ConfigMap why its entries were left out
+func (r *KubernetesOvertimeRepository) QuarantineEntries(ctx context.Context, quarantined []entities.QuarantinedEntry) error {
+	reasons := make(map[string][]string)
+	var sources []string
+	for _, entry := range quarantined {
+		if _, ok := reasons[entry.Source]; !ok {
+			sources = append(sources, entry.Source)
+		}
+		reason := entry.Reason
+		if entry.Line > 0 {
+			reason = fmt.Sprintf("line %d: %s", entry.Line, entry.Reason)
+		}
+		reasons[entry.Source] = append(reasons[entry.Source], reason)
+	}
+
+	cmInterface := r.client.CoreV1().ConfigMaps(r.namespace)
+	for _, source := range sources {
+		cm, err := cmInterface.Get(ctx, source, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting ConfigMap %s: %w", source, err)
+		}
+
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		if cm.Annotations == nil {
+			cm.Annotations = make(map[string]string)
+		}
+		cm.Labels[labelQuarantined] = "true"
+		cm.Annotations[annotationQuarantine] = strings.Join(reasons[source], "\n")
+
+		if _, err := cmInterface.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error updating ConfigMap %s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+// approvalOf reads the approval of the entries of a ConfigMap
+func approvalOf(cm *corev1.ConfigMap) entities.Approval {
+	approval := entities.Approval{
+		Status:   entities.ParseEntryStatus(cm.Labels[labelStatus]),
+		Approver: cm.Annotations[annotationApprover],
+		Reason:   cm.Annotations[annotationReason],
+	}
+	if decidedAt, err := time.Parse(time.RFC3339, cm.Annotations[annotationDecidedAt]); err == nil {
+		approval.DecidedAt = decidedAt
+	}
+	return approval
+}
+
+// deliveryConfigMapName returns the name of the ConfigMap holding the delivery record of a period
+func deliveryConfigMapName(period string) string {
+	return strings.ToLower(period + "-overtime-delivery")
+}
+
+// splitLines splits a newline separated ConfigMap value, dropping empty lines
+func splitLines(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parsePairs parses "key=value" lines into a map
+func parsePairs(value string) map[string]string {
+	pairs := make(map[string]string)
+	for _, line := range splitLines(value) {
+		key, pairValue, _ := strings.Cut(line, "=")
+		pairs[key] = pairValue
+	}
+	return pairs
+}
+
+// formatPairs formats a map as sorted "key=value" lines
+func formatPairs(pairs map[string]string) string {
+	lines := make([]string, 0, len(pairs))
+	for key, value := range pairs {
+		lines = append(lines, key+"="+value)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KubernetesTemplateRepository implements the TemplateRepository interface reading a template
+// stored under a binaryData key of a ConfigMap
+type KubernetesTemplateRepository struct {
+	client    *kubernetes.Clientset
+	namespace string
+	name      string
+	key       string
+}
+
+// NewKubernetesTemplateRepository creates a new template repository reading the given ConfigMap key
+func NewKubernetesTemplateRepository(client *kubernetes.Clientset, namespace, name, key string) *KubernetesTemplateRepository {
+	return &KubernetesTemplateRepository{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		key:       key,
+	}
+}
+
+// GetTemplate reads the template from the ConfigMap
+func (r *KubernetesTemplateRepository) GetTemplate(ctx context.Context) ([]byte, error) {
+	cm, err := r.client.CoreV1().ConfigMaps(r.namespace).Get(ctx, r.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting template ConfigMap %s: %w", r.name, err)
+	}
+
+	content, ok := cm.BinaryData[r.key]
+	if !ok {
+		return nil, fmt.Errorf("template ConfigMap %s has no binaryData key %s", r.name, r.key)
+	}
+	return content, nil
+}
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EntryGroup sums the entries of a report sharing a ticket, project, client, day, week or owner
+type EntryGroup struct {
+	Key             string    // grouping key, e.g. OPS-123, 2026-09-01, 2026-W36 or ana
+	Label           string    // display name: the canonical ticket URL, project or owner as first logged
+	Start           time.Time // first day of day and week groups
+	Entries         []OvertimeEntry
+	Minutes         int
+	BillableMinutes int
+	WeightedMinutes float64
+}
+
+// Count returns the number of entries in the group
+func (g EntryGroup) Count() int {
+	return len(g.Entries)
+}
+
+// ByTicket groups the entries of the group by ticket, e.g. the tickets of a project
+func (g EntryGroup) ByTicket() []EntryGroup {
+	report := OvertimeReport{Entries: g.Entries}
+	return report.ByTicket()
+}
+
+// ByTicket groups the entries by ticket, so URLs of the same ticket add up, ordered by
+// minutes, most worked first
+func (r *OvertimeReport) ByTicket() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		ref := entry.TicketRef()
+		return ref.GroupKey(), ref.URL
+	})
+	sortByMinutes(groups)
+	return groups
+}
+
+// ByProject groups the entries by project: the tracker project when the report was enriched,
+// or the project read from the ticket URL. Entries without a project share the "" group.
+func (r *OvertimeReport) ByProject() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		project := entry.Ticket.Project
+		if project == "" {
+			project = entry.TicketRef().Project
+		}
+		return strings.ToLower(project), project
+	})
+	sortByMinutes(groups)
+	return groups
+}
+
+// ByClient groups the entries by the client of their ticket, set when the report was enriched.
+// Entries without a client share the "" group.
+func (r *OvertimeReport) ByClient() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		return strings.ToLower(entry.Ticket.Client), entry.Ticket.Client
+	})
+	sortByMinutes(groups)
+	return groups
+}
+
+// ByOwner groups the entries by owner, ignoring case, ordered by minutes
+func (r *OvertimeReport) ByOwner() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		owner := strings.TrimSpace(entry.Owner)
+		return strings.ToLower(owner), owner
+	})
+	sortByMinutes(groups)
+	return groups
+}
+
+// ByDay groups the entries by calendar day, in chronological order
+func (r *OvertimeReport) ByDay() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		day := entry.Date.Format("2006-01-02")
+		return day, day
+	})
+	for i := range groups {
+		date := groups[i].Entries[0].Date
+		groups[i].Start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	}
+	sortByStart(groups)
+	return groups
+}
+
+// ByWeek groups the entries by ISO week, starting on Monday, in chronological order
+func (r *OvertimeReport) ByWeek() []EntryGroup {
+	groups := groupEntries(r.Entries, func(entry OvertimeEntry) (string, string) {
+		year, week := entry.Date.ISOWeek()
+		key := fmt.Sprintf("%04d-W%02d", year, week)
+		return key, key
+	})
+	for i := range groups {
+		date := groups[i].Entries[0].Date
+		weekday := (int(date.Weekday()) + 6) % 7 // days since Monday
+		groups[i].Start = time.Date(date.Year(), date.Month(), date.Day()-weekday, 0, 0, 0, 0, date.Location())
+	}
+	sortByStart(groups)
+	return groups
+}
+
+// groupEntries sums the entries sharing a key, in order of first appearance. The label of
+// a group is the label of its first entry.
+func groupEntries(entries []OvertimeEntry, keyOf func(OvertimeEntry) (key, label string)) []EntryGroup {
+	var groups []EntryGroup
+	indexes := make(map[string]int)
+	for _, entry := range entries {
+		key, label := keyOf(entry)
+		i, ok := indexes[key]
+		if !ok {
+			i = len(groups)
+			indexes[key] = i
+			groups = append(groups, EntryGroup{Key: key, Label: label})
+		}
+
+		group := &groups[i]
+		group.Entries = append(group.Entries, entry)
+		group.Minutes += entry.Minutes
+		group.BillableMinutes += entry.BillableMinutes
+		group.WeightedMinutes += entry.WeightedMinutes()
+	}
+	return groups
+}
+
+// sortByMinutes orders groups by minutes, most first, keeping the order of first appearance on ties
+func sortByMinutes(groups []EntryGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Minutes > groups[j].Minutes
+	})
+}
+
+// sortByStart orders groups chronologically
+func sortByStart(groups []EntryGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Start.Before(groups[j].Start)
+	})
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// EntryStatus is the approval state of an overtime entry
+type EntryStatus string
+
+// Entry statuses
+const (
+	StatusPending  EntryStatus = "pending"
+	StatusApproved EntryStatus = "approved"
+	StatusRejected EntryStatus = "rejected"
+)
+
+// ParseEntryStatus parses a stored status; empty and unknown values are pending
+func ParseEntryStatus(value string) EntryStatus {
+	switch status := EntryStatus(strings.ToLower(strings.TrimSpace(value))); status {
+	case StatusApproved, StatusRejected:
+		return status
+	default:
+		return StatusPending
+	}
+}
+
+// Approval is the decision of a manager on the entries of a source
+type Approval struct {
+	Status    EntryStatus
+	Approver  string
+	Reason    string // why the entries were rejected
+	DecidedAt time.Time
+}
+
+// ApplyApprovals sets the approval of every entry from the approvals of its source and keeps
+// only the approved entries. Pending entries, including those of unknown sources, are moved
+// to Pending; rejected entries are dropped. Entries without a source were merged before
+// approvals were recorded and cannot be decided on, so they are kept as approved. Totals
+// are recomputed.
+func (r *OvertimeReport) ApplyApprovals(approvals map[string]Approval) {
+	approved := make([]OvertimeEntry, 0, len(r.Entries))
+	r.Pending = nil
+	for _, entry := range r.Entries {
+		if entry.Source == "" {
+			entry.Approval = Approval{Status: StatusApproved}
+			approved = append(approved, entry)
+			continue
+		}
+		entry.Approval = approvals[entry.Source]
+		entry.Approval.Status = ParseEntryStatus(string(entry.Approval.Status))
+		switch entry.Approval.Status {
+		case StatusApproved:
+			approved = append(approved, entry)
+		case StatusPending:
+			r.Pending = append(r.Pending, entry)
+		}
+	}
+	r.Entries = approved
+	r.CalculateTotalMinutes()
+}
+
+// PendingMinutes returns the minutes of the entries awaiting approval
+func (r *OvertimeReport) PendingMinutes() int {
+	total := 0
+	for _, entry := range r.Pending {
+		total += entry.Minutes
+	}
+	return total
+}
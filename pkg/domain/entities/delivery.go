@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// DeliveryReceipt describes a report accepted by a notification service
+type DeliveryReceipt struct {
+	// MessageIDs maps the delivering channel to the identifier it assigned to the message
+	MessageIDs map[string]string
+	Recipients []string
+}
+
+// DeliveryRecord records the delivery of the report of a period
+type DeliveryRecord struct {
+	Period     string
+	SentAt     time.Time
+	MessageIDs map[string]string
+	Recipients []string
+	// AttachmentChecksums maps each attachment file name to its SHA-256
+	AttachmentChecksums map[string]string
+}
+
+// NewDeliveryRecord creates a delivery record for a period from the receipt of the notification
+func NewDeliveryRecord(period string, receipt *DeliveryReceipt, checksums map[string]string) *DeliveryRecord {
+	record := &DeliveryRecord{
+		Period:              period,
+		SentAt:              time.Now().UTC(),
+		MessageIDs:          map[string]string{},
+		AttachmentChecksums: checksums,
+	}
+	if receipt != nil {
+		for channel, id := range receipt.MessageIDs {
+			record.MessageIDs[channel] = id
+		}
+		record.Recipients = append(record.Recipients, receipt.Recipients...)
+	}
+	return record
+}
@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DurationUnit selects how overtime durations are displayed
+type DurationUnit string
+
+// Supported duration units
+const (
+	DurationMinutes      DurationUnit = "minutes"
+	DurationDecimalHours DurationUnit = "decimal"
+	DurationHoursMinutes DurationUnit = "hh_mm"
+)
+
+// RoundingMode selects how decimal hours are rounded
+type RoundingMode string
+
+// Supported rounding modes
+const (
+	RoundNearest RoundingMode = "nearest"
+	RoundUp      RoundingMode = "up"
+	RoundDown    RoundingMode = "down"
+)
+
+// DefaultDurationPrecision is the number of decimal places of decimal hours
+const DefaultDurationPrecision = 2
+
+// DurationFormat describes how durations are displayed. The zero value displays minutes.
+type DurationFormat struct {
+	Unit             DurationUnit
+	Rounding         RoundingMode // rounding of decimal hours, nearest if empty
+	Precision        int          // decimal places of decimal hours
+	DecimalSeparator string       // "." if empty
+}
+
+// ParseDurationFormat builds a duration format from its configuration values
+func ParseDurationFormat(unit, rounding string, precision int) (DurationFormat, error) {
+	format := DurationFormat{
+		Unit:      DurationUnit(strings.ToLower(unit)),
+		Rounding:  RoundingMode(strings.ToLower(rounding)),
+		Precision: precision,
+	}
+
+	switch format.Unit {
+	case "":
+		format.Unit = DurationMinutes
+	case DurationMinutes, DurationDecimalHours, DurationHoursMinutes:
+	default:
+		return DurationFormat{}, fmt.Errorf("unsupported duration format %q, expected minutes, decimal or hh_mm", unit)
+	}
+
+	switch format.Rounding {
+	case "":
+		format.Rounding = RoundNearest
+	case RoundNearest, RoundUp, RoundDown:
+	default:
+		return DurationFormat{}, fmt.Errorf("unsupported duration rounding %q, expected nearest, up or down", rounding)
+	}
+
+	if precision < 0 {
+		return DurationFormat{}, fmt.Errorf("invalid duration precision %d", precision)
+	}
+
+	return format, nil
+}
+
+// OrDefault returns the format, or minutes with decimal hours rounded to two places for the zero value
+func (f DurationFormat) OrDefault() DurationFormat {
+	if f.Unit == "" {
+		return DurationFormat{Unit: DurationMinutes, Rounding: RoundNearest, Precision: DefaultDurationPrecision}
+	}
+	return f
+}
+
+// Hours converts minutes to decimal hours, rounded to the format precision
+func (f DurationFormat) Hours(minutes int) float64 {
+	scale := math.Pow(10, float64(f.Precision))
+	value := float64(minutes) / 60 * scale
+
+	// The epsilon keeps exact values such as 1.5 hours from being rounded away
+	switch f.Rounding {
+	case RoundUp:
+		value = math.Ceil(value - 1e-9)
+	case RoundDown:
+		value = math.Floor(value + 1e-9)
+	default:
+		value = math.Round(value)
+	}
+	return value / scale
+}
+
+// Format formats minutes in the format unit
+func (f DurationFormat) Format(minutes int) string {
+	switch f.Unit {
+	case DurationDecimalHours:
+		hours := strconv.FormatFloat(f.Hours(minutes), 'f', f.Precision, 64)
+		if f.DecimalSeparator != "" {
+			hours = strings.Replace(hours, ".", f.DecimalSeparator, 1)
+		}
+		return hours
+	case DurationHoursMinutes:
+		return FormatHoursMinutes(minutes)
+	default:
+		return strconv.Itoa(minutes)
+	}
+}
+
+// FormatHoursMinutes formats minutes as HH:MM, without wrapping at 24 hours
+func FormatHoursMinutes(minutes int) string {
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, minutes/60, minutes%60)
+}
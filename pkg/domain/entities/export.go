@@ -0,0 +1,19 @@
+package entities
+
+// ExportFormat describes a format reports can be exported to
+type ExportFormat struct {
+	// Name identifies the format in configuration, e.g. "xlsx"
+	Name string
+	// Extension is the file extension including the dot, e.g. ".xlsx"
+	Extension string
+	// MIMEType is the content type of exported files
+	MIMEType string
+}
+
+// Attachment is an exported report file sent with a notification
+type Attachment struct {
+	// Path is the location of the exported file
+	Path string
+	// MIMEType is the content type of the export format
+	MIMEType string
+}
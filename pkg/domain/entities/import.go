@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TrackedEntry is a time entry exported from a time tracker such as Toggl or Clockify
+type TrackedEntry struct {
+	Start       time.Time
+	End         time.Time
+	Project     string
+	Tags        []string
+	Description string
+	User        string // email of the user when exported, or the user name
+	Line        int    // position of the entry in the export, starting at 1
+	ID          string // identifier of the entry in its origin, e.g. a calendar event UID
+	URL         string // link attached to the entry, e.g. the URL of a calendar event
+	Notes       string // longer text searched for ticket links and keys, not copied to the entry
+}
+
+// Minutes returns the tracked duration in minutes
+func (e TrackedEntry) Minutes() int {
+	return int(e.End.Sub(e.Start).Round(time.Minute) / time.Minute)
+}
+
+// ImportRules turns tracked time into overtime entries
+type ImportRules struct {
+	// Tickets maps projects and tags, matched ignoring case, to ticket URLs
+	Tickets map[string]string
+	// OvertimeTags mark entries that are overtime as a whole; the other entries only count
+	// the minutes outside the working hours unless AllOvertime is set, e.g. for on-call events
+	OvertimeTags []string
+	AllOvertime  bool
+	WorkingHours WorkingHours
+	// JiraBaseURL turns Jira keys found in descriptions and project names into ticket URLs
+	JiraBaseURL string
+	// Owner of the entries exported without a user
+	Owner string
+}
+
+// SkippedEntry is a tracked entry left out of an import, and why
+type SkippedEntry struct {
+	Entry  TrackedEntry
+	Reason string
+}
+
+// String describes the skipped entry for logs and previews
+func (s SkippedEntry) String() string {
+	return fmt.Sprintf("line %d (%s, %s): %s", s.Entry.Line, s.Entry.Start.Format("2006-01-02 15:04"), s.Entry.Description, s.Reason)
+}
+
+// ImportResult is the outcome of an import: the overtime entries and the skipped tracked entries
+type ImportResult struct {
+	Entries []OvertimeEntry
+	Skipped []SkippedEntry
+	Source  string // record the entries were written to, empty for previews
+}
+
+// TotalMinutes returns the imported overtime minutes
+func (r *ImportResult) TotalMinutes() int {
+	total := 0
+	for _, entry := range r.Entries {
+		total += entry.Minutes
+	}
+	return total
+}
+
+// ticketURLPattern finds ticket links in descriptions
+var ticketURLPattern = regexp.MustCompile(`https?://\S+`)
+
+// Convert keeps the overtime part of the tracked entries and links each one to its ticket
+func (r ImportRules) Convert(tracked []TrackedEntry) ImportResult {
+	var result ImportResult
+	for _, entry := range tracked {
+		skip := func(reason string, args ...interface{}) {
+			result.Skipped = append(result.Skipped, SkippedEntry{Entry: entry, Reason: fmt.Sprintf(reason, args...)})
+		}
+
+		if !entry.End.After(entry.Start) {
+			skip("the entry has no duration")
+			continue
+		}
+		minutes := r.overtimeMinutes(entry)
+		if minutes <= 0 {
+			skip("within working hours")
+			continue
+		}
+		ticket := r.ticketOf(entry)
+		if ticket == "" {
+			skip("no ticket for project %q", entry.Project)
+			continue
+		}
+
+		owner := entry.User
+		if owner == "" {
+			owner = r.Owner
+		}
+		result.Entries = append(result.Entries, OvertimeEntry{
+			TicketURL:   ticket,
+			Minutes:     minutes,
+			Date:        entry.Start.In(r.WorkingHours.location()),
+			Owner:       owner,
+			Description: entry.Description,
+			Line:        entry.Line,
+			ExternalID:  entry.ID,
+		})
+	}
+	return result
+}
+
+// overtimeMinutes counts the whole entry when it has an overtime tag, or the time outside
+// the working hours otherwise
+func (r ImportRules) overtimeMinutes(entry TrackedEntry) int {
+	if r.AllOvertime {
+		return entry.Minutes()
+	}
+	for _, tag := range entry.Tags {
+		for _, overtime := range r.OvertimeTags {
+			if strings.EqualFold(strings.TrimSpace(tag), strings.TrimSpace(overtime)) {
+				return entry.Minutes()
+			}
+		}
+	}
+	return r.WorkingHours.MinutesOutside(entry.Start, entry.End)
+}
+
+// ticketOf finds the ticket of an entry: its URL, a link in its description or notes, the
+// ticket mapped to its project or one of its tags, or a Jira key in its texts or project
+func (r ImportRules) ticketOf(entry TrackedEntry) string {
+	for _, text := range []string{entry.URL, entry.Description, entry.Notes} {
+		if link := ticketURLPattern.FindString(text); link != "" {
+			return strings.TrimRight(link, ".,;)")
+		}
+	}
+
+	for _, name := range append([]string{entry.Project}, entry.Tags...) {
+		for key, ticket := range r.Tickets {
+			if name != "" && strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(key)) {
+				return ticket
+			}
+		}
+	}
+
+	if r.JiraBaseURL != "" {
+		for _, text := range []string{entry.Description, entry.Notes, entry.Project} {
+			if key := jiraKeyPattern.FindString(text); key != "" {
+				return strings.TrimSuffix(r.JiraBaseURL, "/") + "/browse/" + key
+			}
+		}
+	}
+	return ""
+}
@@ -1,12 +1,46 @@
 package entities
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // OvertimeEntry represents a single overtime record
 type OvertimeEntry struct {
-	TicketURL string
-	Minutes   int
-	Date      time.Time
+	TicketURL   string
+	Minutes     int
+	Date        time.Time
+	Owner       string
+	Description string
+	// Multiplier weights the minutes, e.g. 1.5 for weekday and 2 for holiday overtime.
+	// Zero means the entry is not weighted.
+	Multiplier float64
+	// BillableMinutes are the minutes after the rounding policy of the report, set with the report totals
+	BillableMinutes int
+	// Source identifies the record the entry was logged in; entries are approved by source
+	Source   string
+	Line     int // position of the entry in its source, starting at 1
+	Approval Approval
+	// Ticket holds the tracker metadata of the ticket, empty unless the report was enriched
+	Ticket TicketInfo
+	// ExternalID identifies imported entries in their origin, e.g. a calendar event UID, so
+	// they are not imported twice
+	ExternalID string
+}
+
+// WeightedMinutes returns the minutes weighted by the entry multiplier
+func (e OvertimeEntry) WeightedMinutes() float64 {
+	if e.Multiplier == 0 {
+		return float64(e.Minutes)
+	}
+	return float64(e.Minutes) * e.Multiplier
+}
+
+// WeightedGroup sums the entries sharing a multiplier
+type WeightedGroup struct {
+	Multiplier      float64
+	Minutes         int
+	WeightedMinutes float64
 }
 
 // OvertimeReport represents a collection of overtime entries for a reporting period
@@ -15,18 +49,33 @@ type OvertimeReport struct {
 	Period     string
 	TotalTime  int
 	ReportDate time.Time
+	// Rounding bills the raw minutes in client increments; BillableTime is the rounded total
+	Rounding     RoundingPolicies
+	BillableTime int
+	// Violations lists the days and months above the overtime limits, set when limits are checked
+	Violations []PolicyViolation
+	// Pending lists the entries awaiting approval, left out of the entries and totals
+	Pending []OvertimeEntry
 }
 
-// CalculateTotalMinutes computes the total minutes from all entries
+// CalculateTotalMinutes computes the total minutes from all entries, and their billable
+// minutes under the report rounding policies
 func (r *OvertimeReport) CalculateTotalMinutes() int {
 	total := 0
 	for _, entry := range r.Entries {
 		total += entry.Minutes
 	}
 	r.TotalTime = total
+	r.BillableTime = r.Rounding.billableMinutes(r.Entries)
 	return total
 }
 
+// SetRounding applies rounding policies to the report and recomputes its totals
+func (r *OvertimeReport) SetRounding(policies RoundingPolicies) {
+	r.Rounding = policies
+	r.CalculateTotalMinutes()
+}
+
 // NewOvertimeReport creates a new overtime report for the given period
 func NewOvertimeReport(period string) *OvertimeReport {
 	return &OvertimeReport{
@@ -45,4 +94,66 @@ func (r *OvertimeReport) AddEntry(ticketURL string, minutes int) {
 	}
 	r.Entries = append(r.Entries, entry)
 	r.CalculateTotalMinutes()
-}
\ No newline at end of file
+}
+
+// AppendEntry adds an existing overtime entry to the report, keeping its date and details
+func (r *OvertimeReport) AppendEntry(entry OvertimeEntry) {
+	r.Entries = append(r.Entries, entry)
+	r.CalculateTotalMinutes()
+}
+
+// WeightedBreakdown groups the entries by multiplier, ordered by multiplier.
+// It returns nil when no entry is weighted.
+func (r *OvertimeReport) WeightedBreakdown() []WeightedGroup {
+	weighted := false
+	groups := make(map[float64]*WeightedGroup)
+	for _, entry := range r.Entries {
+		multiplier := entry.Multiplier
+		if multiplier == 0 {
+			multiplier = 1
+		}
+		if multiplier != 1 {
+			weighted = true
+		}
+
+		group, ok := groups[multiplier]
+		if !ok {
+			group = &WeightedGroup{Multiplier: multiplier}
+			groups[multiplier] = group
+		}
+		group.Minutes += entry.Minutes
+		group.WeightedMinutes += entry.WeightedMinutes()
+	}
+
+	if !weighted {
+		return nil
+	}
+
+	breakdown := make([]WeightedGroup, 0, len(groups))
+	for _, group := range groups {
+		breakdown = append(breakdown, *group)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Multiplier < breakdown[j].Multiplier
+	})
+	return breakdown
+}
+
+// TotalWeightedMinutes computes the sum of the weighted minutes of all entries
+func (r *OvertimeReport) TotalWeightedMinutes() float64 {
+	total := 0.0
+	for _, entry := range r.Entries {
+		total += entry.WeightedMinutes()
+	}
+	return total
+}
+
+// Enriched reports whether any entry carries tracker metadata
+func (r *OvertimeReport) Enriched() bool {
+	for _, entry := range r.Entries {
+		if entry.Ticket.Key != "" {
+			return true
+		}
+	}
+	return false
+}
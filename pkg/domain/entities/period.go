@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// PeriodLayout is the layout of report periods, e.g. "Sep-2026"
+const PeriodLayout = "Jan-2006"
+
+// PeriodFor returns the report period containing the given time
+func PeriodFor(t time.Time) string {
+	return t.Format(PeriodLayout)
+}
+
+// ParsePeriod parses a report period written as "Sep-2026" or "2026-09"
+// and returns the first day of the month
+func ParsePeriod(value string) (time.Time, error) {
+	for _, layout := range []string{PeriodLayout, "2006-01"} {
+		if month, err := time.Parse(layout, value); err == nil {
+			return month, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid period %q, expected Jan-2006 or 2006-01", value)
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// OvertimeLimits caps the overtime of each owner. Zero limits are not checked.
+type OvertimeLimits struct {
+	DailyMinutes   int // labor rules allow 120 minutes a day
+	MonthlyMinutes int // contract cap of a month
+}
+
+// Enabled reports whether any limit is checked
+func (l OvertimeLimits) Enabled() bool {
+	return l.DailyMinutes > 0 || l.MonthlyMinutes > 0
+}
+
+// ViolationKind tells which limit a violation exceeds
+type ViolationKind string
+
+// Violation kinds
+const (
+	ViolationDaily   ViolationKind = "daily"
+	ViolationMonthly ViolationKind = "monthly"
+)
+
+// PolicyViolation is a day or month in which an owner logged more overtime than allowed
+type PolicyViolation struct {
+	Kind    ViolationKind
+	Owner   string    // empty for entries without owner
+	Date    time.Time // day of daily violations
+	Period  string    // month of monthly violations
+	Minutes int
+	Limit   int
+}
+
+// Excess returns the minutes above the limit
+func (v PolicyViolation) Excess() int {
+	return v.Minutes - v.Limit
+}
+
+// PolicyAlert notifies a manager of the violations found while processing a period
+type PolicyAlert struct {
+	Period     string
+	Manager    string // recipient of the alert, the default recipient if empty
+	Violations []PolicyViolation
+}
+
+// Check returns the violations of the entries of a period: daily violations ordered by day
+// and owner, followed by the monthly violations ordered by owner. Owners are compared
+// case-insensitively.
+func (l OvertimeLimits) Check(period string, entries []OvertimeEntry) []PolicyViolation {
+	type ownerDay struct {
+		owner string
+		day   time.Time
+	}
+	daily := make(map[ownerDay]int)
+	monthly := make(map[string]int)
+	names := make(map[string]string)
+	for _, entry := range entries {
+		owner := strings.ToLower(entry.Owner)
+		if _, ok := names[owner]; !ok {
+			names[owner] = entry.Owner
+		}
+		day := time.Date(entry.Date.Year(), entry.Date.Month(), entry.Date.Day(), 0, 0, 0, 0, time.UTC)
+		daily[ownerDay{owner, day}] += entry.Minutes
+		monthly[owner] += entry.Minutes
+	}
+
+	var violations []PolicyViolation
+	if l.DailyMinutes > 0 {
+		for key, minutes := range daily {
+			if minutes > l.DailyMinutes {
+				violations = append(violations, PolicyViolation{
+					Kind:    ViolationDaily,
+					Owner:   names[key.owner],
+					Date:    key.day,
+					Period:  period,
+					Minutes: minutes,
+					Limit:   l.DailyMinutes,
+				})
+			}
+		}
+	}
+	if l.MonthlyMinutes > 0 {
+		for owner, minutes := range monthly {
+			if minutes > l.MonthlyMinutes {
+				violations = append(violations, PolicyViolation{
+					Kind:    ViolationMonthly,
+					Owner:   names[owner],
+					Period:  period,
+					Minutes: minutes,
+					Limit:   l.MonthlyMinutes,
+				})
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Kind != b.Kind {
+			return a.Kind == ViolationDaily
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Owner < b.Owner
+	})
+	return violations
+}
@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoundingScope selects what a billing increment is applied to
+type RoundingScope string
+
+// Supported rounding scopes
+const (
+	RoundPerEntry RoundingScope = "entry"
+	RoundPerDay   RoundingScope = "day"
+)
+
+// RoundingPolicy rounds overtime minutes to the billing increment of a client.
+// The zero value bills the raw minutes.
+type RoundingPolicy struct {
+	Increment int           // billing block in minutes, e.g. 15 or 30; no rounding if zero
+	Mode      RoundingMode  // nearest if empty
+	Scope     RoundingScope // per entry if empty
+}
+
+// ParseRoundingPolicy parses a policy written as increment[/mode[/scope]], e.g. "15/up/day"
+func ParseRoundingPolicy(value string) (RoundingPolicy, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(value)), "/")
+
+	increment, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || increment < 0 {
+		return RoundingPolicy{}, fmt.Errorf("invalid rounding increment %q, expected minutes", parts[0])
+	}
+	policy := RoundingPolicy{Increment: increment, Mode: RoundNearest, Scope: RoundPerEntry}
+
+	if len(parts) > 1 {
+		policy.Mode = RoundingMode(strings.TrimSpace(parts[1]))
+		if policy.Mode != RoundNearest && policy.Mode != RoundUp && policy.Mode != RoundDown {
+			return RoundingPolicy{}, fmt.Errorf("unsupported rounding mode %q, expected nearest, up or down", parts[1])
+		}
+	}
+	if len(parts) > 2 {
+		policy.Scope = RoundingScope(strings.TrimSpace(parts[2]))
+		if policy.Scope != RoundPerEntry && policy.Scope != RoundPerDay {
+			return RoundingPolicy{}, fmt.Errorf("unsupported rounding scope %q, expected entry or day", parts[2])
+		}
+	}
+	if len(parts) > 3 {
+		return RoundingPolicy{}, fmt.Errorf("invalid rounding policy %q, expected increment/mode/scope", value)
+	}
+
+	return policy, nil
+}
+
+// Round rounds minutes to the policy increment; half blocks round up with the nearest mode
+func (p RoundingPolicy) Round(minutes int) int {
+	if p.Increment <= 0 || minutes <= 0 {
+		return minutes
+	}
+
+	blocks := minutes / p.Increment
+	remainder := minutes % p.Increment
+	switch {
+	case remainder == 0:
+	case p.Mode == RoundUp:
+		blocks++
+	case p.Mode == RoundDown:
+	case remainder*2 >= p.Increment:
+		blocks++
+	}
+	return blocks * p.Increment
+}
+
+// RoundingRule applies a policy to the tickets of a client or project. Client and project
+// rules select on the ticket metadata, ignoring case; rules with neither select tickets by
+// the beginning of their URL, e.g. "https://acme.atlassian.net/browse/OPS-"
+type RoundingRule struct {
+	Client  string
+	Project string // project name or key
+	Prefix  string
+	Policy  RoundingPolicy
+}
+
+// RoundingPolicies holds the default policy and the client and project rules.
+// The zero value bills the raw minutes.
+type RoundingPolicies struct {
+	Default RoundingPolicy
+	Rules   []RoundingRule
+}
+
+// Enabled reports whether any policy rounds minutes
+func (p RoundingPolicies) Enabled() bool {
+	if p.Default.Increment > 0 {
+		return true
+	}
+	for _, rule := range p.Rules {
+		if rule.Policy.Increment > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyFor returns the policy of an entry: the rule of its project, then of its client,
+// then of the longest URL prefix matching its ticket, or the default
+func (p RoundingPolicies) PolicyFor(entry OvertimeEntry) RoundingPolicy {
+	policy, _ := p.match(entry)
+	return policy
+}
+
+// match returns the policy of an entry and the index of its rule, -1 for the default
+func (p RoundingPolicies) match(entry OvertimeEntry) (RoundingPolicy, int) {
+	// The project key of the URL matches too, since enriched projects are named
+	projects := []string{entry.Ticket.Project, entry.TicketRef().Project}
+	for i, rule := range p.Rules {
+		for _, project := range projects {
+			if rule.Project != "" && project != "" && strings.EqualFold(rule.Project, project) {
+				return rule.Policy, i
+			}
+		}
+	}
+	for i, rule := range p.Rules {
+		if rule.Client != "" && strings.EqualFold(rule.Client, entry.Ticket.Client) {
+			return rule.Policy, i
+		}
+	}
+
+	policy, index, longest := p.Default, -1, -1
+	for i, rule := range p.Rules {
+		if rule.Client != "" || rule.Project != "" {
+			continue
+		}
+		if strings.HasPrefix(entry.TicketURL, rule.Prefix) && len(rule.Prefix) > longest {
+			policy, index, longest = rule.Policy, i, len(rule.Prefix)
+		}
+	}
+	return policy, index
+}
+
+// billingDay groups the entries of a day billed under the same rule
+type billingDay struct {
+	rule int
+	day  time.Time
+}
+
+// billableMinutes rounds the entries in place and returns the billable total. Per day
+// policies bill each day's total; the rounding difference is added to the last entry of
+// the day, or taken from the last entries without making any of them negative.
+func (p RoundingPolicies) billableMinutes(entries []OvertimeEntry) int {
+	days := make(map[billingDay][]int)
+	policies := make(map[billingDay]RoundingPolicy)
+	for i := range entries {
+		entry := &entries[i]
+		policy, rule := p.match(*entry)
+		entry.BillableMinutes = entry.Minutes
+		if policy.Scope != RoundPerDay {
+			entry.BillableMinutes = policy.Round(entry.Minutes)
+			continue
+		}
+
+		key := billingDay{rule: rule, day: time.Date(entry.Date.Year(), entry.Date.Month(), entry.Date.Day(), 0, 0, 0, 0, time.UTC)}
+		days[key] = append(days[key], i)
+		policies[key] = policy
+	}
+
+	// Map iteration order does not matter, each day only touches its own entries
+	for key, indexes := range days {
+		minutes := 0
+		for _, i := range indexes {
+			minutes += entries[i].Minutes
+		}
+		diff := policies[key].Round(minutes) - minutes
+
+		sort.Ints(indexes)
+		if diff > 0 {
+			entries[indexes[len(indexes)-1]].BillableMinutes += diff
+		}
+		for j := len(indexes) - 1; j >= 0 && diff < 0; j-- {
+			taken := min(entries[indexes[j]].BillableMinutes, -diff)
+			entries[indexes[j]].BillableMinutes -= taken
+			diff += taken
+		}
+	}
+
+	total := 0
+	for _, entry := range entries {
+		total += entry.BillableMinutes
+	}
+	return total
+}
@@ -0,0 +1,203 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default session rules: commits less than two hours apart belong to the same session, and
+// half an hour of work is assumed before the first commit of a session
+const (
+	DefaultSessionGap = 2 * time.Hour
+	DefaultLeadTime   = 30 * time.Minute
+)
+
+// Commit is a commit read from a local git repository
+type Commit struct {
+	Hash       string
+	Repository string // name of the repository directory
+	Author     string
+	Email      string
+	Time       time.Time // author time, kept across rebases
+	Message    string
+}
+
+// CommitSession is a run of commits outside the working hours, close enough in time to be
+// a single stretch of work
+type CommitSession struct {
+	Start   time.Time // first commit minus the lead time
+	End     time.Time // last commit
+	Commits []Commit
+	Tickets []string // ticket URLs, or Jira keys without a Jira URL, in order of first mention
+	Minutes int      // minutes of the session outside the working hours
+}
+
+// SuggestRules infer overtime from commits
+type SuggestRules struct {
+	WorkingHours WorkingHours
+	SessionGap   time.Duration // DefaultSessionGap if zero
+	LeadTime     time.Duration // DefaultLeadTime if zero
+	// JiraBaseURL turns Jira keys found in commit messages into ticket URLs
+	JiraBaseURL string
+}
+
+// Sessions clusters the commits made outside the working hours into sessions, in order
+func (r SuggestRules) Sessions(commits []Commit) []CommitSession {
+	gap, lead := r.SessionGap, r.LeadTime
+	if gap <= 0 {
+		gap = DefaultSessionGap
+	}
+	if lead <= 0 {
+		lead = DefaultLeadTime
+	}
+
+	var outside []Commit
+	for _, commit := range commits {
+		if !r.WorkingHours.Contains(commit.Time) {
+			outside = append(outside, commit)
+		}
+	}
+	sort.SliceStable(outside, func(i, j int) bool { return outside[i].Time.Before(outside[j].Time) })
+
+	var sessions []CommitSession
+	for _, commit := range outside {
+		if n := len(sessions); n > 0 && commit.Time.Sub(sessions[n-1].End) <= gap {
+			sessions[n-1].End = commit.Time
+			sessions[n-1].Commits = append(sessions[n-1].Commits, commit)
+			continue
+		}
+		sessions = append(sessions, CommitSession{Start: commit.Time.Add(-lead), End: commit.Time, Commits: []Commit{commit}})
+	}
+
+	for i := range sessions {
+		sessions[i].Minutes = r.WorkingHours.MinutesOutside(sessions[i].Start, sessions[i].End)
+		sessions[i].Tickets = r.tickets(sessions[i].Commits)
+	}
+	return sessions
+}
+
+// tickets finds the ticket links and Jira keys mentioned in commit messages
+func (r SuggestRules) tickets(commits []Commit) []string {
+	var tickets []string
+	seen := make(map[string]bool)
+	add := func(ticket string) {
+		if ticket != "" && !seen[ticket] {
+			seen[ticket] = true
+			tickets = append(tickets, ticket)
+		}
+	}
+	for _, commit := range commits {
+		linked := make(map[string]bool)
+		for _, link := range ticketURLPattern.FindAllString(commit.Message, -1) {
+			link = strings.TrimRight(link, ".,;)")
+			ref := ParseTicketRef(link, r.JiraBaseURL)
+			if ref.Provider == ProviderUnknown {
+				add(link)
+				continue
+			}
+			linked[ref.Key] = true
+			add(ref.URL)
+		}
+		// Keys of the linked tickets are not tickets of their own
+		for _, key := range jiraKeyPattern.FindAllString(commit.Message, -1) {
+			if linked[key] {
+				continue
+			}
+			if r.JiraBaseURL != "" {
+				key = strings.TrimSuffix(r.JiraBaseURL, "/") + "/browse/" + key
+			}
+			add(key)
+		}
+	}
+	return tickets
+}
+
+// Entries proposes overtime entries for the sessions, splitting the minutes of a session
+// evenly among its tickets. Sessions without tickets are proposed without a ticket URL.
+func (r SuggestRules) Entries(sessions []CommitSession, owner string) []OvertimeEntry {
+	var entries []OvertimeEntry
+	for _, session := range sessions {
+		if session.Minutes <= 0 {
+			continue
+		}
+		tickets := session.Tickets
+		if len(tickets) == 0 {
+			tickets = []string{""}
+		}
+		for i, ticket := range tickets {
+			minutes := session.Minutes / len(tickets)
+			if i == 0 {
+				minutes += session.Minutes % len(tickets)
+			}
+			entries = append(entries, OvertimeEntry{
+				TicketURL:   ticket,
+				Minutes:     minutes,
+				Date:        session.Commits[0].Time.In(r.WorkingHours.location()),
+				Owner:       owner,
+				Description: session.Description(),
+			})
+		}
+	}
+	return entries
+}
+
+// Description summarizes the commits of a session, e.g. "3 commits in api, web"
+func (s CommitSession) Description() string {
+	var repositories []string
+	seen := make(map[string]bool)
+	for _, commit := range s.Commits {
+		if !seen[commit.Repository] {
+			seen[commit.Repository] = true
+			repositories = append(repositories, commit.Repository)
+		}
+	}
+	return fmt.Sprintf("%d commits in %s", len(s.Commits), strings.Join(repositories, ", "))
+}
+
+// Suggestion is the overtime inferred from commits, and its comparison with the logged entries
+type Suggestion struct {
+	Sessions []CommitSession
+	Entries  []OvertimeEntry
+	Days     []DayComparison // set when compared with the logged entries
+}
+
+// DayComparison compares the overtime suggested from commits with the overtime logged on a day
+type DayComparison struct {
+	Date      time.Time
+	Suggested int
+	Logged    int
+}
+
+// Difference returns the suggested minutes missing from the log, negative when more was logged
+func (d DayComparison) Difference() int {
+	return d.Suggested - d.Logged
+}
+
+// CompareDays sums the suggested and logged minutes of each day, in chronological order
+func CompareDays(suggested, logged []OvertimeEntry) []DayComparison {
+	days := make(map[string]*DayComparison)
+	var comparisons []*DayComparison
+	add := func(entries []OvertimeEntry, count func(*DayComparison, int)) {
+		report := OvertimeReport{Entries: entries}
+		for _, group := range report.ByDay() {
+			day, ok := days[group.Key]
+			if !ok {
+				day = &DayComparison{Date: group.Start}
+				days[group.Key] = day
+				comparisons = append(comparisons, day)
+			}
+			count(day, group.Minutes)
+		}
+	}
+	add(suggested, func(day *DayComparison, minutes int) { day.Suggested += minutes })
+	add(logged, func(day *DayComparison, minutes int) { day.Logged += minutes })
+
+	sort.SliceStable(comparisons, func(i, j int) bool { return comparisons[i].Date.Before(comparisons[j].Date) })
+	result := make([]DayComparison, 0, len(comparisons))
+	for _, day := range comparisons {
+		result = append(result, *day)
+	}
+	return result
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// DailySummary describes the result of processing the overtime entries of a single day
+type DailySummary struct {
+	Date        time.Time
+	Entries     []OvertimeEntry
+	MonthReport *OvertimeReport
+	Quarantined []QuarantinedEntry // entries of the day left out as invalid
+}
+
+// TotalMinutes computes the total minutes logged on the summarized day
+func (s *DailySummary) TotalMinutes() int {
+	total := 0
+	for _, entry := range s.Entries {
+		total += entry.Minutes
+	}
+	return total
+}
+
+// Reminder asks an on-call owner to log the overtime of a day without entries
+type Reminder struct {
+	Owner string
+	Date  time.Time
+}
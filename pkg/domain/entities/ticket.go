@@ -0,0 +1,250 @@
+package entities
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TicketInfo is the tracker metadata of a ticket, shown next to its URL in reports
+type TicketInfo struct {
+	Key     string // e.g. OPS-123
+	Title   string
+	Project string
+	Client  string
+}
+
+// Label returns "KEY Title" when the ticket was enriched, or "" otherwise
+func (t TicketInfo) Label() string {
+	return strings.TrimSpace(t.Key + " " + t.Title)
+}
+
+// TicketProvider identifies the issue tracker of a ticket URL
+type TicketProvider string
+
+// Recognized issue trackers
+const (
+	ProviderUnknown     TicketProvider = ""
+	ProviderJira        TicketProvider = "jira"
+	ProviderGitHub      TicketProvider = "github"
+	ProviderGitLab      TicketProvider = "gitlab"
+	ProviderAzureDevOps TicketProvider = "azure_devops"
+)
+
+// TicketRef is a ticket URL resolved to its tracker, project and key
+type TicketRef struct {
+	Provider TicketProvider
+	Project  string // Jira project key, or the owner/repository path of the other trackers
+	Key      string // e.g. OPS-123, acme/api#12 or acme/api!7 for GitLab merge requests
+	URL      string // canonical URL of the ticket
+}
+
+// GroupKey identifies the ticket when grouping entries: its key, or the normalized URL
+// for unrecognized trackers
+func (r TicketRef) GroupKey() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	return r.URL
+}
+
+// jiraKeyPattern matches Jira issue keys, a project key followed by the issue number
+var jiraKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[0-9]+\b`)
+
+// ParseTicketRef recognizes Jira, GitHub, GitLab and Azure DevOps ticket URLs. Other URLs
+// keep an unknown provider; all URLs are normalized so that the same ticket compares equal
+// regardless of trailing slashes, fragments, host case or, for recognized trackers, the
+// query string and the form of the path. Jira is recognized by its /browse/KEY paths and
+// selectedIssue parameters on any host, and by its project issue paths on the host of the
+// given Jira base URL, when configured.
+func ParseTicketRef(ticketURL string, jiraBaseURL ...string) TicketRef {
+	raw := strings.TrimSpace(ticketURL)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return TicketRef{URL: strings.TrimSuffix(raw, "/")}
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	parsed.RawPath = ""
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	var ref TicketRef
+	switch host := parsed.Host; {
+	case host == "github.com":
+		ref = parseGitHubRef(segments)
+	case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+		if ref = parseAzureDevOpsRef(host, segments); ref.Provider != ProviderUnknown {
+			parsed.Host = "dev.azure.com"
+		}
+	case strings.Contains(host, "gitlab"):
+		ref = parseGitLabRef(segments)
+	default:
+		var jira *url.URL
+		for _, base := range jiraBaseURL {
+			if candidate, err := url.Parse(strings.TrimSpace(base)); err == nil && strings.EqualFold(candidate.Host, host) {
+				jira = candidate
+			}
+		}
+		ref = parseJiraRef(parsed, segments, jira)
+	}
+
+	if ref.Provider == ProviderUnknown {
+		ref.URL = parsed.String()
+		return ref
+	}
+	canonical := url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: ref.URL}
+	ref.URL = canonical.String()
+	return ref
+}
+
+// jiraContextMarkers end the context path of Jira Server installations in the paths of
+// their boards and projects; Jira Cloud paths start with /jira/software and the like.
+var jiraContextMarkers = []string{"/secure/", "/jira/software/", "/jira/core/", "/jira/servicedesk/", "/projects/"}
+
+// parseJiraRef finds the issue key of /browse/KEY paths, of the selectedIssue parameter of
+// boards and, on the configured Jira host, of project issue paths such as
+// /projects/OPS/issues/OPS-1. The canonical path keeps the context path of Jira Server
+// installations, e.g. /jira/browse/OPS-1. Other URLs, even with a key in their path, are
+// not Jira tickets.
+func parseJiraRef(parsed *url.URL, segments []string, jira *url.URL) TicketRef {
+	jiraKey := func(value string) string {
+		if key := jiraKeyPattern.FindString(value); key == value {
+			return key
+		}
+		return ""
+	}
+	ref := func(prefix, key string) TicketRef {
+		project, _, _ := strings.Cut(key, "-")
+		return TicketRef{Provider: ProviderJira, Project: project, Key: key, URL: strings.TrimSuffix(prefix, "/") + "/browse/" + key}
+	}
+
+	if prefix, rest, found := strings.Cut(parsed.Path, "/browse/"); found {
+		key, _, _ := strings.Cut(rest, "/")
+		if key = jiraKey(key); key != "" {
+			return ref(prefix, key)
+		}
+	}
+
+	if key := jiraKey(strings.TrimSpace(parsed.Query().Get("selectedIssue"))); key != "" {
+		if jira != nil {
+			return ref(jira.Path, key)
+		}
+		for _, marker := range jiraContextMarkers {
+			if prefix, _, found := strings.Cut(parsed.Path, marker); found {
+				return ref(prefix, key)
+			}
+		}
+		return ref("", key)
+	}
+
+	if jira != nil {
+		for i := 1; i < len(segments); i++ {
+			if key := jiraKey(segments[i]); key != "" && segments[i-1] == "issues" {
+				return ref(jira.Path, key)
+			}
+		}
+	}
+	return TicketRef{}
+}
+
+// parseGitHubRef reads owner/repo/issues/N and owner/repo/pull/N paths
+func parseGitHubRef(segments []string) TicketRef {
+	if len(segments) < 4 || (segments[2] != "issues" && segments[2] != "pull") || !isNumber(segments[3]) {
+		return TicketRef{}
+	}
+	project := strings.ToLower(segments[0] + "/" + segments[1])
+	return TicketRef{
+		Provider: ProviderGitHub,
+		Project:  project,
+		Key:      project + "#" + segments[3],
+		URL:      "/" + project + "/" + segments[2] + "/" + segments[3],
+	}
+}
+
+// parseGitLabRef reads group/project/-/issues/N and merge request paths, with nested
+// groups, and the older paths without the "-" separator
+func parseGitLabRef(segments []string) TicketRef {
+	for i := 1; i+1 < len(segments); i++ {
+		kind, number := segments[i], segments[i+1]
+		if (kind != "issues" && kind != "merge_requests" && kind != "work_items") || !isNumber(number) {
+			continue
+		}
+		end := i
+		if segments[i-1] == "-" {
+			end = i - 1
+		}
+		if end == 0 {
+			return TicketRef{}
+		}
+
+		project := strings.ToLower(strings.Join(segments[:end], "/"))
+		separator := "#"
+		if kind == "merge_requests" {
+			separator = "!"
+		} else {
+			kind = "issues"
+		}
+		return TicketRef{
+			Provider: ProviderGitLab,
+			Project:  project,
+			Key:      project + separator + number,
+			URL:      "/" + project + "/-/" + kind + "/" + number,
+		}
+	}
+	return TicketRef{}
+}
+
+// parseAzureDevOpsRef reads org/project/_workitems/edit/N paths, on dev.azure.com or on
+// the legacy org.visualstudio.com hosts
+func parseAzureDevOpsRef(host string, segments []string) TicketRef {
+	if org, ok := strings.CutSuffix(host, ".visualstudio.com"); ok {
+		segments = append([]string{org}, segments...)
+	}
+	if len(segments) < 5 || segments[2] != "_workitems" || segments[3] != "edit" || !isNumber(segments[4]) {
+		return TicketRef{}
+	}
+
+	project := segments[0] + "/" + segments[1]
+	return TicketRef{
+		Provider: ProviderAzureDevOps,
+		Project:  project,
+		Key:      project + "#" + segments[4],
+		URL:      "/" + project + "/_workitems/edit/" + segments[4],
+	}
+}
+
+// isNumber reports whether a path segment is a ticket number
+func isNumber(segment string) bool {
+	_, err := strconv.ParseUint(segment, 10, 64)
+	return err == nil
+}
+
+// TicketRef resolves the ticket URL of the entry
+func (e OvertimeEntry) TicketRef() TicketRef {
+	return ParseTicketRef(e.TicketURL)
+}
+
+// CanonicalTicketURL returns the canonical URL of a recognized ticket, and the ticket URL as
+// given otherwise, so links of unknown trackers are never rewritten
+func (e OvertimeEntry) CanonicalTicketURL(jiraBaseURL string) string {
+	if ref := ParseTicketRef(e.TicketURL, jiraBaseURL); ref.Provider != ProviderUnknown {
+		return ref.URL
+	}
+	return e.TicketURL
+}
+
+// NormalizeTickets rewrites the ticket URL of every entry of a recognized tracker in its
+// canonical form, so entries of the same ticket are grouped together
+func (r *OvertimeReport) NormalizeTickets(jiraBaseURL string) {
+	for i := range r.Entries {
+		r.Entries[i].TicketURL = r.Entries[i].CanonicalTicketURL(jiraBaseURL)
+	}
+	for i := range r.Pending {
+		r.Pending[i].TicketURL = r.Pending[i].CanonicalTicketURL(jiraBaseURL)
+	}
+}
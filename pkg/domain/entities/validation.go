@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxEntryMinutes rejects entries longer than a day
+const DefaultMaxEntryMinutes = 24 * 60
+
+// EntryRules validates overtime entries before they are merged
+type EntryRules struct {
+	MaxMinutes int // longest accepted entry, DefaultMaxEntryMinutes if zero
+}
+
+// Validate returns why an entry is invalid, or nil when it can be merged
+func (r EntryRules) Validate(entry OvertimeEntry) error {
+	maxMinutes := r.MaxMinutes
+	if maxMinutes <= 0 {
+		maxMinutes = DefaultMaxEntryMinutes
+	}
+
+	if strings.TrimSpace(entry.TicketURL) == "" {
+		return fmt.Errorf("empty ticket URL")
+	}
+	if ticket, err := url.Parse(entry.TicketURL); err != nil || (ticket.Scheme != "http" && ticket.Scheme != "https") || ticket.Host == "" {
+		return fmt.Errorf("invalid ticket URL %q", entry.TicketURL)
+	}
+	if entry.Minutes <= 0 {
+		return fmt.Errorf("minutes must be positive, got %d", entry.Minutes)
+	}
+	if entry.Minutes > maxMinutes {
+		return fmt.Errorf("%d minutes exceed the maximum of %d", entry.Minutes, maxMinutes)
+	}
+	if entry.Multiplier < 0 {
+		return fmt.Errorf("negative multiplier %v", entry.Multiplier)
+	}
+	return nil
+}
+
+// Split separates the valid entries from those to quarantine
+func (r EntryRules) Split(entries []OvertimeEntry) ([]OvertimeEntry, []QuarantinedEntry) {
+	valid := make([]OvertimeEntry, 0, len(entries))
+	var quarantined []QuarantinedEntry
+	for _, entry := range entries {
+		if err := r.Validate(entry); err != nil {
+			quarantined = append(quarantined, QuarantinedEntry{
+				Source: entry.Source,
+				Line:   entry.Line,
+				Ticket: entry.TicketURL,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		valid = append(valid, entry)
+	}
+	return valid, quarantined
+}
+
+// QuarantinedEntry is an entry left out of the reports because it is malformed or invalid
+type QuarantinedEntry struct {
+	Source string // record the entry was logged in
+	Line   int    // line of the entry in its source, zero when the whole source is rejected
+	Ticket string
+	Reason string
+}
+
+// String describes the quarantined entry for logs and messages
+func (q QuarantinedEntry) String() string {
+	where := q.Source
+	if where == "" {
+		where = "unknown source"
+	}
+	if q.Line > 0 {
+		where = fmt.Sprintf("%s line %d", where, q.Line)
+	}
+	return fmt.Sprintf("%s: %s", where, q.Reason)
+}
@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkingHours is the regular schedule of the team; time worked outside it is overtime
+type WorkingHours struct {
+	Start    time.Duration // offset of the start of the day from midnight, e.g. 9h
+	End      time.Duration // offset of the end of the day from midnight, e.g. 18h
+	Days     []time.Weekday
+	Location *time.Location // time zone of the schedule, UTC if nil
+}
+
+// DefaultWorkingHours is 09:00 to 18:00 from Monday to Friday
+var DefaultWorkingHours = WorkingHours{
+	Start: 9 * time.Hour,
+	End:   18 * time.Hour,
+	Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+}
+
+// weekdayNames maps the names accepted in working days to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	"dom": time.Sunday, "seg": time.Monday, "ter": time.Tuesday, "qua": time.Wednesday,
+	"qui": time.Thursday, "sex": time.Friday, "sab": time.Saturday,
+}
+
+// ParseWorkingHours parses a schedule such as "09:00-18:00", working days such as "mon-fri"
+// or "mon,wed,fri" and an IANA time zone. Empty values keep the defaults.
+func ParseWorkingHours(hours, days, timezone string) (WorkingHours, error) {
+	schedule := DefaultWorkingHours
+	if hours = strings.TrimSpace(hours); hours != "" {
+		from, to, ok := strings.Cut(hours, "-")
+		if !ok {
+			return schedule, fmt.Errorf("invalid working hours %q, expected HH:MM-HH:MM", hours)
+		}
+		start, err := parseClock(from)
+		if err != nil {
+			return schedule, err
+		}
+		end, err := parseClock(to)
+		if err != nil {
+			return schedule, err
+		}
+		if end <= start {
+			return schedule, fmt.Errorf("invalid working hours %q, the end must be after the start", hours)
+		}
+		schedule.Start, schedule.End = start, end
+	}
+
+	if days = strings.TrimSpace(days); days != "" {
+		parsed, err := parseWeekdays(days)
+		if err != nil {
+			return schedule, err
+		}
+		schedule.Days = parsed
+	}
+
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return schedule, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+		}
+		schedule.Location = location
+	}
+	return schedule, nil
+}
+
+// parseClock parses a time of day as HH:MM
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// parseWeekdays parses a comma separated list of days or ranges of days, e.g. "mon-fri,sun"
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, item := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(strings.ToLower(strings.TrimSpace(item)), "-")
+		first, ok := weekdayNames[from]
+		if !ok {
+			return nil, fmt.Errorf("invalid working day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[to]; !ok {
+				return nil, fmt.Errorf("invalid working day %q", to)
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// location returns the time zone of the schedule
+func (w WorkingHours) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+// isWorkingDay reports whether the schedule works on a weekday
+func (w WorkingHours) isWorkingDay(day time.Weekday) bool {
+	for _, working := range w.Days {
+		if working == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether an instant falls within the working hours
+func (w WorkingHours) Contains(t time.Time) bool {
+	local := t.In(w.location())
+	if !w.isWorkingDay(local.Weekday()) {
+		return false
+	}
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	return !local.Before(midnight.Add(w.Start)) && local.Before(midnight.Add(w.End))
+}
+
+// MinutesOutside returns the minutes between start and end that fall outside the working hours
+func (w WorkingHours) MinutesOutside(start, end time.Time) int {
+	if !end.After(start) {
+		return 0
+	}
+
+	inside := time.Duration(0)
+	local := start.In(w.location())
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !w.isWorkingDay(day.Weekday()) {
+			continue
+		}
+		from, to := day.Add(w.Start), day.Add(w.End)
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
+		}
+		if to.After(from) {
+			inside += to.Sub(from)
+		}
+	}
+	return int((end.Sub(start) - inside).Round(time.Minute) / time.Minute)
+}
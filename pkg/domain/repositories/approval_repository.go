@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// ApprovalRepository stores the approval of overtime entries by the source they were logged in
+type ApprovalRepository interface {
+	// GetApprovals returns the approval of every entry source, pending when undecided
+	GetApprovals(ctx context.Context) (map[string]entities.Approval, error)
+
+	// SetApproval records a decision on the entries of a source
+	SetApproval(ctx context.Context, source string, approval entities.Approval) error
+}
@@ -8,6 +8,28 @@ import (
 
 // NotificationService defines the interface for sending notifications
 type NotificationService interface {
-	// SendReportByEmail sends an overtime report via email with an attachment
-	SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachmentPath string) error
-}
\ No newline at end of file
+	// SendReportByEmail sends an overtime report via email with the given attachments
+	// and returns the receipt of the delivery
+	SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error)
+}
+
+// DailySummaryNotifier is implemented by notification services that can also
+// publish the result of the daily processing run
+type DailySummaryNotifier interface {
+	// SendDailySummary sends the summary of a processed day
+	SendDailySummary(ctx context.Context, summary *entities.DailySummary) error
+}
+
+// ReminderNotifier is implemented by notification services that can remind
+// owners to log their overtime
+type ReminderNotifier interface {
+	// SendReminder reminds an owner about a day without overtime entries
+	SendReminder(ctx context.Context, reminder *entities.Reminder) error
+}
+
+// PolicyAlertNotifier is implemented by notification services that can alert a
+// manager about overtime above the configured limits
+type PolicyAlertNotifier interface {
+	// SendPolicyAlert sends the violations found while processing a period
+	SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error
+}
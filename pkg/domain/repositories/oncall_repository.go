@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// OnCallRepository defines the interface for accessing the on-call schedule
+type OnCallRepository interface {
+	// GetOnCallOwners returns the owners that were on-call on the given day
+	GetOnCallOwners(ctx context.Context, day time.Time) ([]string, error)
+}
@@ -9,8 +9,19 @@ import (
 
 // OvertimeRepository defines the interface for accessing overtime data
 type OvertimeRepository interface {
-	// GetOvertimeEntriesForPeriod fetches overtime entries for a specific time period
-	GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, error)
+	// GetOvertimeEntriesForPeriod fetches overtime entries for a specific time period, along with
+	// the malformed entries that could not be read
+	GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, []entities.QuarantinedEntry, error)
+	
+	// SaveOvertimeEntries records new entries in a single source, processed with the entries
+	// logged on the day it is saved, and returns the name of the source
+	SaveOvertimeEntries(ctx context.Context, entries []entities.OvertimeEntry) (string, error)
+	
+	// GetImportedIDs returns the external IDs of the saved entries, mapped to their source
+	GetImportedIDs(ctx context.Context) (map[string]string, error)
+	
+	// QuarantineEntries records why entries were left out, next to their source
+	QuarantineEntries(ctx context.Context, quarantined []entities.QuarantinedEntry) error
 	
 	// SaveOvertimeReport persists an overtime report
 	SaveOvertimeReport(ctx context.Context, report *entities.OvertimeReport) error
@@ -20,4 +31,10 @@ type OvertimeRepository interface {
 	
 	// MergeOvertimeEntries combines multiple overtime entries into a single report
 	MergeOvertimeEntries(ctx context.Context, entries []entities.OvertimeEntry, period string) (*entities.OvertimeReport, error)
+	
+	// GetDeliveryRecord retrieves the delivery record of a period, or nil if its report was never delivered
+	GetDeliveryRecord(ctx context.Context, period string) (*entities.DeliveryRecord, error)
+	
+	// SaveDeliveryRecord persists the delivery record of a period
+	SaveDeliveryRecord(ctx context.Context, record *entities.DeliveryRecord) error
 }
\ No newline at end of file
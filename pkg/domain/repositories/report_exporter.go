@@ -2,15 +2,19 @@ package repositories
 
 import (
 	"context"
+	"io"
 
 	"github.com/MateSousa/overtime-script/pkg/domain/entities"
 )
 
 // ReportExporter defines the interface for exporting reports to different formats
 type ReportExporter interface {
-	// ExportToExcel exports the report to an Excel file
-	ExportToExcel(ctx context.Context, report *entities.OvertimeReport) (string, error)
-	
-	// ExportToCSV exports the report to a CSV file (for backward compatibility)
-	ExportToCSV(ctx context.Context, report *entities.OvertimeReport) (string, error)
-}
\ No newline at end of file
+	// Export writes the report to w in the named format
+	Export(ctx context.Context, report *entities.OvertimeReport, format string, w io.Writer) error
+
+	// Format returns the metadata of the named format
+	Format(name string) (entities.ExportFormat, error)
+
+	// Formats lists the supported formats
+	Formats() []entities.ExportFormat
+}
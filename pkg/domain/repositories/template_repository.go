@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// TemplateRepository defines the interface for loading a customer provided report template
+type TemplateRepository interface {
+	// GetTemplate returns the raw template file
+	GetTemplate(ctx context.Context) ([]byte, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+// ErrTicketNotFound is returned when the tracker has no ticket with the requested key
+var ErrTicketNotFound = errors.New("ticket not found")
+
+// TicketRepository defines the interface for fetching ticket metadata from an issue tracker
+type TicketRepository interface {
+	// GetTicket returns the metadata of the ticket with the given key, or ErrTicketNotFound
+	GetTicket(ctx context.Context, key string) (*entities.TicketInfo, error)
+}
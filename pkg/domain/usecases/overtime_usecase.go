@@ -2,17 +2,135 @@ package usecases
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
 	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
 )
 
+// ErrReportAlreadyDelivered is returned when the report of a period was already delivered
+var ErrReportAlreadyDelivered = errors.New("report already delivered")
+
 // OvertimeUseCase defines the overtime business logic
 type OvertimeUseCase struct {
 	repository         repositories.OvertimeRepository
 	reportExporter     repositories.ReportExporter
 	notificationService repositories.NotificationService
+	notifyDaily         bool
+	onCallRepository    repositories.OnCallRepository
+	reportFormats       []string
+	outputDir           string
+	rounding            entities.RoundingPolicies
+	limits              entities.OvertimeLimits
+	policyAlerts        bool
+	manager             string
+	approvals           repositories.ApprovalRepository
+	entryRules          entities.EntryRules
+	tickets             repositories.TicketRepository
+	jiraBaseURL         string
+}
+
+// Option configures optional behaviour of the overtime use case
+type Option func(*OvertimeUseCase)
+
+// WithDailySummary enables sending the daily processing result through the
+// notification service, when the service supports it
+func WithDailySummary(enabled bool) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.notifyDaily = enabled
+	}
+}
+
+// WithOnCallReminders enables reminding on-call owners that logged no overtime
+// on the processed day, using the given on-call schedule
+func WithOnCallReminders(schedule repositories.OnCallRepository) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.onCallRepository = schedule
+	}
+}
+
+// WithReportFormats selects the export formats attached to the monthly report,
+// e.g. "xlsx" and "csv". The report is exported to Excel only by default.
+func WithReportFormats(formats ...string) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.reportFormats = formats
+	}
+}
+
+// WithOutputDir sets the directory where report files are exported before being sent.
+// The system temporary directory is used by default.
+func WithOutputDir(dir string) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.outputDir = dir
+	}
+}
+
+// WithRoundingPolicies bills the report minutes in client increments. Reports keep the raw
+// minutes next to the billable ones; minutes are not rounded by default.
+func WithRoundingPolicies(policies entities.RoundingPolicies) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.rounding = policies
+	}
+}
+
+// WithOvertimeLimits checks the entries against daily and monthly overtime limits. Violations
+// are logged while processing each day and listed in the monthly report exports.
+func WithOvertimeLimits(limits entities.OvertimeLimits) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.limits = limits
+	}
+}
+
+// WithPolicyAlerts sends the limit violations to a manager through the notification service,
+// when the service supports it. An empty manager uses the default recipient of the service.
+func WithPolicyAlerts(manager string) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.policyAlerts = true
+		uc.manager = manager
+	}
+}
+
+// WithApprovals requires entries to be approved before they are included in the monthly
+// report. Pending entries are listed apart from the report totals; rejected entries are left out.
+func WithApprovals(approvals repositories.ApprovalRepository) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.approvals = approvals
+	}
+}
+
+// WithEntryRules sets the rules entries must pass to be merged. Invalid entries are
+// quarantined; entries longer than a day are rejected by default.
+func WithEntryRules(rules entities.EntryRules) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.entryRules = rules
+	}
+}
+
+// WithTicketEnrichment adds the title, project and client of each ticket to the monthly
+// report, fetched from the given tracker. Only the tickets on the host of the Jira base URL
+// set with WithJiraBaseURL are fetched; other tickets, and tickets that cannot be fetched,
+// are left as URLs.
+func WithTicketEnrichment(tickets repositories.TicketRepository) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.tickets = tickets
+	}
+}
+
+// WithJiraBaseURL recognizes the issue links of the Jira installation at the given URL in
+// all their forms, besides the /browse/KEY links recognized on any host
+func WithJiraBaseURL(baseURL string) Option {
+	return func(uc *OvertimeUseCase) {
+		uc.jiraBaseURL = baseURL
+	}
 }
 
 // NewOvertimeUseCase creates a new overtime use case instance
@@ -20,12 +138,18 @@ func NewOvertimeUseCase(
 	repo repositories.OvertimeRepository,
 	exporter repositories.ReportExporter,
 	notifier repositories.NotificationService,
+	opts ...Option,
 ) *OvertimeUseCase {
-	return &OvertimeUseCase{
+	uc := &OvertimeUseCase{
 		repository:         repo,
 		reportExporter:     exporter,
 		notificationService: notifier,
+		reportFormats:       []string{"xlsx"},
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // ProcessYesterdayOvertime collects and processes overtime entries from yesterday
@@ -37,75 +161,483 @@ func (uc *OvertimeUseCase) ProcessYesterdayOvertime(ctx context.Context) error {
 	endOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, yesterday.Location())
 	
 	// Get yesterday's overtime entries
-	entries, err := uc.repository.GetOvertimeEntriesForPeriod(ctx, startOfYesterday, endOfYesterday)
+	entries, quarantined, err := uc.repository.GetOvertimeEntriesForPeriod(ctx, startOfYesterday, endOfYesterday)
 	if err != nil {
 		return fmt.Errorf("error getting yesterday's overtime entries: %w", err)
 	}
 	
+	// Keep invalid entries out of the report instead of merging them with wrong values
+	entries, invalid := uc.entryRules.Split(entries)
+	quarantined = append(quarantined, invalid...)
+	
+	// Store canonical ticket URLs, so the entries of a ticket are grouped together
+	for i := range entries {
+		entries[i].TicketURL = entries[i].CanonicalTicketURL(uc.jiraBaseURL)
+	}
+	if len(quarantined) > 0 {
+		for _, entry := range quarantined {
+			fmt.Printf("Warning: quarantined entry %s\n", entry)
+		}
+		// Entries stay in their sources, so a failure to record the reason loses nothing
+		if err := uc.repository.QuarantineEntries(ctx, quarantined); err != nil {
+			fmt.Printf("Warning: error recording quarantined entries: %v\n", err)
+		}
+	}
+	
 	// Create and save the monthly report
-	currentMonthPeriod := now.Format("Jan-2006")
+	currentMonthPeriod := entities.PeriodFor(now)
 	report, err := uc.repository.MergeOvertimeEntries(ctx, entries, currentMonthPeriod)
 	if err != nil {
 		return fmt.Errorf("error merging overtime entries: %w", err)
 	}
+	report.SetRounding(uc.rounding)
 	
 	// Save the updated report
 	if err := uc.repository.SaveOvertimeReport(ctx, report); err != nil {
 		return fmt.Errorf("error saving overtime report: %w", err)
 	}
 	
+	// Flag the limits crossed by yesterday's entries, with the same failure policy as the daily summary
+	if uc.limits.Enabled() {
+		crossed := uc.checkLimits(report, entries, startOfYesterday)
+		for _, violation := range crossed {
+			fmt.Printf("Warning: overtime limit exceeded: %s\n", describeViolation(violation))
+		}
+		if len(crossed) > 0 {
+			uc.sendPolicyAlert(ctx, report.Period, crossed)
+		}
+	}
+	
+	// Publish the daily result; entries are already saved, so a failed
+	// notification must not fail the run and cause the entries to be merged twice
+	if notifier, ok := uc.notificationService.(repositories.DailySummaryNotifier); ok && uc.notifyDaily {
+		summary := &entities.DailySummary{
+			Date:        startOfYesterday,
+			Entries:     entries,
+			MonthReport: report,
+			Quarantined: quarantined,
+		}
+		if err := notifier.SendDailySummary(ctx, summary); err != nil {
+			fmt.Printf("Warning: error sending daily summary: %v\n", err)
+		}
+	}
+	
+	// Remind on-call owners that forgot to log, with the same failure policy
+	if uc.onCallRepository != nil {
+		if _, err := uc.RemindMissingEntries(ctx, startOfYesterday, entries); err != nil {
+			fmt.Printf("Warning: error sending reminders: %v\n", err)
+		}
+	}
+	
 	return nil
 }
 
-// GenerateMonthlyReport generates the report for the previous month and sends it via email
-func (uc *OvertimeUseCase) GenerateMonthlyReport(ctx context.Context) error {
-	// Calculate previous month
-	now := time.Now()
-	prevMonth := now.AddDate(0, -1, 0)
-	monthPeriod := prevMonth.Format("Jan-2006")
+// RemindMissingEntries notifies every owner on-call on the given day that has
+// none of the given entries, and returns the reminders sent
+func (uc *OvertimeUseCase) RemindMissingEntries(ctx context.Context, day time.Time, entries []entities.OvertimeEntry) ([]entities.Reminder, error) {
+	if uc.onCallRepository == nil {
+		return nil, fmt.Errorf("on-call reminders are not configured")
+	}
 	
-	// Get the merged report for the previous month
-	report, err := uc.repository.GetMergedReport(ctx, monthPeriod)
-	if err != nil {
-		return fmt.Errorf("error getting merged report for %s: %w", monthPeriod, err)
+	notifier, ok := uc.notificationService.(repositories.ReminderNotifier)
+	if !ok {
+		return nil, fmt.Errorf("notification service does not support reminders")
 	}
 	
-	// Export the report to Excel
-	excelFilePath, err := uc.reportExporter.ExportToExcel(ctx, report)
+	owners, err := uc.onCallRepository.GetOnCallOwners(ctx, day)
 	if err != nil {
-		return fmt.Errorf("error exporting report to Excel: %w", err)
+		return nil, fmt.Errorf("error getting on-call owners: %w", err)
 	}
 	
-	// Send the report via email
-	if err := uc.notificationService.SendReportByEmail(ctx, report, excelFilePath); err != nil {
-		return fmt.Errorf("error sending report email: %w", err)
+	// Owners with at least one entry on the day do not need a reminder
+	logged := make(map[string]bool)
+	for _, entry := range entries {
+		logged[strings.ToLower(entry.Owner)] = true
+	}
+	
+	var sent []entities.Reminder
+	for _, owner := range owners {
+		if logged[strings.ToLower(owner)] {
+			continue
+		}
+		
+		reminder := entities.Reminder{Owner: owner, Date: day}
+		if err := notifier.SendReminder(ctx, &reminder); err != nil {
+			return sent, fmt.Errorf("error sending reminder to %s: %w", owner, err)
+		}
+		sent = append(sent, reminder)
+	}
+	
+	return sent, nil
+}
+
+// checkLimits sets the violations of the report and returns those caused by the given new
+// entries: the daily limits exceeded on day and the monthly limits the entries crossed
+func (uc *OvertimeUseCase) checkLimits(report *entities.OvertimeReport, entries []entities.OvertimeEntry, day time.Time) []entities.PolicyViolation {
+	report.Violations = uc.limits.Check(report.Period, report.Entries)
+	
+	added := make(map[string]int)
+	for _, entry := range entries {
+		added[strings.ToLower(entry.Owner)] += entry.Minutes
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	
+	var crossed []entities.PolicyViolation
+	for _, violation := range report.Violations {
+		switch violation.Kind {
+		case entities.ViolationDaily:
+			if violation.Date.Equal(day) {
+				crossed = append(crossed, violation)
+			}
+		case entities.ViolationMonthly:
+			minutes := added[strings.ToLower(violation.Owner)]
+			if minutes > 0 && violation.Minutes-minutes <= violation.Limit {
+				crossed = append(crossed, violation)
+			}
+		}
+	}
+	return crossed
+}
+
+// sendPolicyAlert alerts the manager about violations, when alerts are enabled and supported.
+// Failures are only logged, since the entries and reports are already processed.
+func (uc *OvertimeUseCase) sendPolicyAlert(ctx context.Context, period string, violations []entities.PolicyViolation) {
+	if !uc.policyAlerts {
+		return
+	}
+	notifier, ok := uc.notificationService.(repositories.PolicyAlertNotifier)
+	if !ok {
+		fmt.Println("Warning: notification service does not support policy alerts")
+		return
 	}
 	
+	alert := &entities.PolicyAlert{Period: period, Manager: uc.manager, Violations: violations}
+	if err := notifier.SendPolicyAlert(ctx, alert); err != nil {
+		fmt.Printf("Warning: error sending policy alert: %v\n", err)
+	}
+}
+
+// describeViolation formats a violation for the logs
+func describeViolation(violation entities.PolicyViolation) string {
+	owner := violation.Owner
+	if owner == "" {
+		owner = "unknown owner"
+	}
+	if violation.Kind == entities.ViolationDaily {
+		return fmt.Sprintf("%s logged %d minutes on %s, limit %d", owner, violation.Minutes, violation.Date.Format("2006-01-02"), violation.Limit)
+	}
+	return fmt.Sprintf("%s logged %d minutes in %s, limit %d", owner, violation.Minutes, violation.Period, violation.Limit)
+}
+
+// ApproveEntries approves the entries logged in a source for the monthly report
+func (uc *OvertimeUseCase) ApproveEntries(ctx context.Context, source, approver string) error {
+	return uc.decide(ctx, source, entities.Approval{Status: entities.StatusApproved, Approver: approver})
+}
+
+// RejectEntries leaves the entries logged in a source out of the monthly report
+func (uc *OvertimeUseCase) RejectEntries(ctx context.Context, source, approver, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to reject entries")
+	}
+	return uc.decide(ctx, source, entities.Approval{Status: entities.StatusRejected, Approver: approver, Reason: reason})
+}
+
+// decide records the decision of an approver on the entries of a source
+func (uc *OvertimeUseCase) decide(ctx context.Context, source string, approval entities.Approval) error {
+	if uc.approvals == nil {
+		return fmt.Errorf("entry approvals are not configured")
+	}
+	if source == "" || approval.Approver == "" {
+		return fmt.Errorf("an entry source and approver are required")
+	}
+	
+	approval.DecidedAt = time.Now().UTC()
+	if err := uc.approvals.SetApproval(ctx, source, approval); err != nil {
+		return fmt.Errorf("error saving approval of %s: %w", source, err)
+	}
 	return nil
 }
 
-// TestMonthlyReport generates a test report for the current month and sends it via email
+// ImportEntries converts time exported from a time tracker or calendar into overtime entries.
+// Entries the entry rules would quarantine, entries whose external ID was already imported and
+// entries dated outside the open period are skipped: saved entries are merged into the report
+// of the current month by the next daily run, whatever their dates. The entries are only
+// written when apply is set, so the result can be previewed first.
+func (uc *OvertimeUseCase) ImportEntries(ctx context.Context, tracked []entities.TrackedEntry, rules entities.ImportRules, apply bool) (*entities.ImportResult, error) {
+	result := rules.Convert(tracked)
+	
+	imported, err := uc.repository.GetImportedIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting imported entries: %w", err)
+	}
+	
+	open := entities.PeriodFor(time.Now())
+	converted := result.Entries
+	result.Entries = nil
+	for _, entry := range converted {
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, entities.SkippedEntry{
+				Entry:  entities.TrackedEntry{Start: entry.Date, Description: entry.Description, User: entry.Owner, Line: entry.Line, ID: entry.ExternalID},
+				Reason: reason,
+			})
+		}
+		if err := uc.entryRules.Validate(entry); err != nil {
+			skip(err.Error())
+			continue
+		}
+		if period := entities.PeriodFor(entry.Date); period != open {
+			skip(fmt.Sprintf("dated in %s, only entries of the open period %s can be imported", period, open))
+			continue
+		}
+		if entry.ExternalID != "" {
+			if source, ok := imported[entry.ExternalID]; ok && source == "" {
+				skip(fmt.Sprintf("duplicate of an earlier entry with ID %s", entry.ExternalID))
+				continue
+			} else if ok {
+				skip(fmt.Sprintf("already imported in %s", source))
+				continue
+			}
+			// Entries of this import are recorded without a source
+			imported[entry.ExternalID] = ""
+		}
+		entry.TicketURL = entry.CanonicalTicketURL(uc.jiraBaseURL)
+		result.Entries = append(result.Entries, entry)
+	}
+	
+	if !apply || len(result.Entries) == 0 {
+		return &result, nil
+	}
+	source, err := uc.repository.SaveOvertimeEntries(ctx, result.Entries)
+	if err != nil {
+		return &result, fmt.Errorf("error saving imported entries: %w", err)
+	}
+	result.Source = source
+	return &result, nil
+}
+
+// SuggestOvertime proposes overtime entries from the commits of an owner made outside the
+// working hours. When compare is set, the proposal is compared day by day with the entries
+// the owner logged in the month.
+func (uc *OvertimeUseCase) SuggestOvertime(ctx context.Context, commits []entities.Commit, rules entities.SuggestRules, owner, month string, compare bool) (*entities.Suggestion, error) {
+	suggestion := &entities.Suggestion{Sessions: rules.Sessions(commits)}
+	suggestion.Entries = rules.Entries(suggestion.Sessions, owner)
+	if !compare {
+		return suggestion, nil
+	}
+	
+	report, err := uc.repository.GetMergedReport(ctx, month)
+	if err != nil {
+		return nil, fmt.Errorf("error getting merged report for %s: %w", month, err)
+	}
+	var logged []entities.OvertimeEntry
+	for _, entry := range report.Entries {
+		if owner == "" || strings.EqualFold(strings.TrimSpace(entry.Owner), owner) {
+			logged = append(logged, entry)
+		}
+	}
+	suggestion.Days = entities.CompareDays(suggestion.Entries, logged)
+	return suggestion, nil
+}
+
+// GenerateMonthlyReport generates the report for the previous month and sends it via email.
+// Periods that were already delivered are skipped with ErrReportAlreadyDelivered.
+func (uc *OvertimeUseCase) GenerateMonthlyReport(ctx context.Context) error {
+	// Calculate previous month
+	now := time.Now()
+	prevMonth := now.AddDate(0, -1, 0)
+	monthPeriod := entities.PeriodFor(prevMonth)
+	
+	return uc.deliverReport(ctx, monthPeriod, false, true)
+}
+
+// ResendMonthlyReport sends the report of the given month again, even if it was already delivered
+func (uc *OvertimeUseCase) ResendMonthlyReport(ctx context.Context, month string) error {
+	monthStart, err := entities.ParsePeriod(month)
+	if err != nil {
+		return err
+	}
+	
+	return uc.deliverReport(ctx, entities.PeriodFor(monthStart), true, true)
+}
+
+// TestMonthlyReport generates a test report for the current month and sends it via email.
+// Test deliveries are not recorded, so they never block the real monthly report.
 func (uc *OvertimeUseCase) TestMonthlyReport(ctx context.Context) error {
 	// Get current month
 	now := time.Now()
-	monthPeriod := now.Format("Jan-2006")
+	monthPeriod := entities.PeriodFor(now)
 	
-	// Get the merged report for the current month
+	return uc.deliverReport(ctx, monthPeriod, true, false)
+}
+
+// deliverReport exports the merged report of a period and sends it. Unless forced, periods
+// with a delivery record are skipped; when record is set a new delivery record is saved.
+func (uc *OvertimeUseCase) deliverReport(ctx context.Context, monthPeriod string, force, record bool) error {
+	if !force {
+		delivery, err := uc.repository.GetDeliveryRecord(ctx, monthPeriod)
+		if err != nil {
+			return fmt.Errorf("error getting delivery record for %s: %w", monthPeriod, err)
+		}
+		if delivery != nil {
+			return fmt.Errorf("%w: %s on %s", ErrReportAlreadyDelivered, monthPeriod, delivery.SentAt.Format(time.RFC3339))
+		}
+	}
+	
+	// Get the merged report for the period
 	report, err := uc.repository.GetMergedReport(ctx, monthPeriod)
 	if err != nil {
 		return fmt.Errorf("error getting merged report for %s: %w", monthPeriod, err)
 	}
+	if uc.approvals != nil {
+		approvals, err := uc.approvals.GetApprovals(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting entry approvals: %w", err)
+		}
+		report.ApplyApprovals(approvals)
+	}
+	report.NormalizeTickets(uc.jiraBaseURL)
+	if uc.tickets != nil {
+		uc.enrichTickets(ctx, report.Entries)
+		uc.enrichTickets(ctx, report.Pending)
+	}
+	report.SetRounding(uc.rounding)
+	if uc.limits.Enabled() {
+		report.Violations = uc.limits.Check(report.Period, report.Entries)
+	}
 	
-	// Export the report to Excel
-	excelFilePath, err := uc.reportExporter.ExportToExcel(ctx, report)
+	// Export the report in every configured format into a directory of its own,
+	// removed once the report is sent so runs never collide or leave files behind
+	runDir, err := os.MkdirTemp(uc.outputDir, "overtime-")
+	if err != nil {
+		return fmt.Errorf("error creating export directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(runDir); err != nil {
+			fmt.Printf("Warning: error removing export directory %s: %v\n", runDir, err)
+		}
+	}()
+
+	attachments, err := uc.exportReport(ctx, report, runDir)
 	if err != nil {
-		return fmt.Errorf("error exporting report to Excel: %w", err)
+		return err
 	}
 	
 	// Send the report via email
-	if err := uc.notificationService.SendReportByEmail(ctx, report, excelFilePath); err != nil {
+	receipt, err := uc.notificationService.SendReportByEmail(ctx, report, attachments)
+	if err != nil {
 		return fmt.Errorf("error sending report email: %w", err)
 	}
 	
+	if !record {
+		return nil
+	}
+	
+	if len(report.Violations) > 0 {
+		uc.sendPolicyAlert(ctx, monthPeriod, report.Violations)
+	}
+	
+	checksums := make(map[string]string)
+	for _, attachment := range attachments {
+		checksum, err := fileChecksum(attachment.Path)
+		if err != nil {
+			fmt.Printf("Warning: error computing checksum of %s: %v\n", attachment.Path, err)
+			continue
+		}
+		checksums[filepath.Base(attachment.Path)] = checksum
+	}
+	
+	// The report is already sent, so failing here would only make a retry send it twice
+	delivery := entities.NewDeliveryRecord(monthPeriod, receipt, checksums)
+	if err := uc.repository.SaveDeliveryRecord(ctx, delivery); err != nil {
+		fmt.Printf("Warning: error saving delivery record for %s: %v\n", monthPeriod, err)
+	}
+	
 	return nil
-}
\ No newline at end of file
+}
+
+// enrichTickets sets the tracker metadata of the entries of tickets of the configured Jira.
+// Keys of other Jira installations are never sent to it. Lookup failures are only logged,
+// since the report is still valid without the metadata.
+func (uc *OvertimeUseCase) enrichTickets(ctx context.Context, entries []entities.OvertimeEntry) {
+	jira, err := url.Parse(uc.jiraBaseURL)
+	if err != nil || jira.Host == "" {
+		return
+	}
+	
+	failed := make(map[string]bool)
+	for i := range entries {
+		ref := entries[i].TicketRef()
+		key := ref.Key
+		if ref.Provider != entities.ProviderJira || failed[key] {
+			continue
+		}
+		if ticket, err := url.Parse(ref.URL); err != nil || !strings.EqualFold(ticket.Host, jira.Host) {
+			continue
+		}
+		
+		info, err := uc.tickets.GetTicket(ctx, key)
+		if err != nil {
+			fmt.Printf("Warning: error fetching ticket %s: %v\n", key, err)
+			failed[key] = true
+			continue
+		}
+		entries[i].Ticket = *info
+	}
+}
+
+// exportReport exports the report in every configured format into dir and returns the file paths
+func (uc *OvertimeUseCase) exportReport(ctx context.Context, report *entities.OvertimeReport, dir string) ([]entities.Attachment, error) {
+	var attachments []entities.Attachment
+	for _, name := range uc.reportFormats {
+		format, err := uc.reportExporter.Format(name)
+		if err != nil {
+			return nil, err
+		}
+		
+		path := filepath.Join(dir, reportFileName(report.Period, format.Extension))
+		if err := uc.exportToFile(ctx, report, format.Name, path); err != nil {
+			return nil, fmt.Errorf("error exporting report to %s: %w", format.Name, err)
+		}
+		attachments = append(attachments, entities.Attachment{Path: path, MIMEType: format.MIMEType})
+	}
+	return attachments, nil
+}
+
+// exportToFile writes the report in the given format to a file
+func (uc *OvertimeUseCase) exportToFile(ctx context.Context, report *entities.OvertimeReport, format, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	
+	if err := uc.reportExporter.Export(ctx, report, format, file); err != nil {
+		file.Close()
+		return err
+	}
+	
+	return file.Close()
+}
+
+// reportFileName names the exported file after the report period, e.g. "overtime_2026-09.xlsx"
+func reportFileName(period, extension string) string {
+	name := strings.ToLower(period)
+	if month, err := entities.ParsePeriod(period); err == nil {
+		name = month.Format("2006-01")
+	}
+	return "overtime_" + name + extension
+}
+
+// fileChecksum returns the hex encoded SHA-256 of a file
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // NewInClusterClient creates a new Kubernetes client using in-cluster configuration
@@ -22,4 +23,23 @@ func NewInClusterClient() (*kubernetes.Clientset, error) {
 	}
 
 	return clientset, nil
-}
\ No newline at end of file
+}
+
+// NewClient creates a new Kubernetes client using in-cluster configuration inside a pod,
+// or the kubeconfig of the user (KUBECONFIG or ~/.kube/config) elsewhere
+func NewClient() (*kubernetes.Clientset, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		loader := clientcmd.NewDefaultClientConfigLoadingRules()
+		if config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, &clientcmd.ConfigOverrides{}).ClientConfig(); err != nil {
+			return nil, fmt.Errorf("error getting in-cluster or kubeconfig config: %w", err)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+
+	return clientset, nil
+}
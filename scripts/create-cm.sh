@@ -1,13 +1,18 @@
 #!/bin/bash
 
 # Check if correct number of arguments
-if [ $# -ne 2 ]; then
-  echo "Usage: $0 <ticket-url> <minutes>"
+if [ $# -lt 2 ] || [ $# -gt 5 ]; then
+  echo "Usage: $0 <ticket-url> <minutes> [owner-email] [multiplier] [description]"
   exit 1
 fi
 
 TICKET_URL=$1
 MINUTES=$2
+# Owner defaults to OVERTIME_OWNER so on-call reminders can match entries
+OWNER=${3:-$OVERTIME_OWNER}
+# Multiplier weights the minutes, e.g. 1.5 or 2 for holidays
+MULTIPLIER=${4:-1}
+DESCRIPTION=$5
 TIMESTAMP=$(date +%Y%m%d%H%M%S)
 CM_NAME="overtime-${TIMESTAMP}"
 
@@ -24,6 +29,10 @@ metadata:
 data:
   ticket_url: "${TICKET_URL}"
   minutes: "${MINUTES}"
+  owner: "${OWNER}"
+  multiplier: "${MULTIPLIER}"
+  date: "$(date +%Y-%m-%d)"
+  description: "${DESCRIPTION}"
 EOF
 
 echo "Created ConfigMap ${CM_NAME} with ticket ${TICKET_URL} and ${MINUTES} minutes"
@@ -105,4 +105,563 @@ func TestCalculateTotalMinutes(t *testing.T) {
 	if report.TotalTime != 150 {
 		t.Errorf("Expected TotalTime field to be 150, got %d", report.TotalTime)
 	}
-}
\ No newline at end of file
+}
+func TestParsePeriod(t *testing.T) {
+	for _, value := range []string{"Sep-2026", "2026-09"} {
+		month, err := entities.ParsePeriod(value)
+		if err != nil {
+			t.Fatalf("Expected no error parsing %s, got %v", value, err)
+		}
+
+		if entities.PeriodFor(month) != "Sep-2026" {
+			t.Errorf("Expected period Sep-2026 for %s, got %s", value, entities.PeriodFor(month))
+		}
+	}
+
+	if _, err := entities.ParsePeriod("2026/09"); err == nil {
+		t.Error("Expected error for an invalid period, got nil")
+	}
+}
+
+func TestWeightedBreakdown(t *testing.T) {
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AddEntry("http://jira.com/ticket1", 60)
+
+	if report.WeightedBreakdown() != nil {
+		t.Error("Expected no breakdown without multipliers")
+	}
+
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 60, Multiplier: 2})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket3", Minutes: 40, Multiplier: 1.5})
+
+	breakdown := report.WeightedBreakdown()
+	if len(breakdown) != 3 {
+		t.Fatalf("Expected 3 groups, got %+v", breakdown)
+	}
+
+	if breakdown[0].Multiplier != 1 || breakdown[1].Multiplier != 1.5 || breakdown[2].Multiplier != 2 {
+		t.Errorf("Expected groups ordered by multiplier, got %+v", breakdown)
+	}
+
+	if breakdown[1].WeightedMinutes != 60 {
+		t.Errorf("Expected 60 weighted minutes at 1.5x, got %v", breakdown[1].WeightedMinutes)
+	}
+
+	if report.TotalWeightedMinutes() != 240 {
+		t.Errorf("Expected 240 total weighted minutes, got %v", report.TotalWeightedMinutes())
+	}
+}
+
+func TestDurationFormat(t *testing.T) {
+	tests := []struct {
+		format   entities.DurationFormat
+		minutes  int
+		expected string
+	}{
+		{entities.DurationFormat{}, 95, "95"},
+		{entities.DurationFormat{Unit: entities.DurationHoursMinutes}, 1505, "25:05"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Precision: 2}, 95, "1.58"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundDown, Precision: 2}, 95, "1.58"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundUp, Precision: 1}, 95, "1.6"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundUp, Precision: 1}, 90, "1.5"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundDown, Precision: 0}, 119, "1"},
+		{entities.DurationFormat{Unit: entities.DurationDecimalHours, Precision: 2, DecimalSeparator: ","}, 90, "1,50"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.Format(tt.minutes); got != tt.expected {
+			t.Errorf("Expected %+v to format %d minutes as %q, got %q", tt.format, tt.minutes, tt.expected, got)
+		}
+	}
+}
+
+func TestParseDurationFormat(t *testing.T) {
+	format, err := entities.ParseDurationFormat("", "", 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format.Unit != entities.DurationMinutes || format.Rounding != entities.RoundNearest {
+		t.Errorf("Expected minutes rounded to nearest by default, got %+v", format)
+	}
+
+	if _, err := entities.ParseDurationFormat("days", "", 2); err == nil {
+		t.Error("Expected error for an unsupported unit, got nil")
+	}
+	if _, err := entities.ParseDurationFormat("decimal", "half", 2); err == nil {
+		t.Error("Expected error for an unsupported rounding, got nil")
+	}
+	if _, err := entities.ParseDurationFormat("decimal", "up", -1); err == nil {
+		t.Error("Expected error for a negative precision, got nil")
+	}
+}
+
+func TestRoundingPolicyRound(t *testing.T) {
+	tests := []struct {
+		policy   entities.RoundingPolicy
+		minutes  int
+		expected int
+	}{
+		{entities.RoundingPolicy{}, 7, 7},
+		{entities.RoundingPolicy{Increment: 15}, 7, 0},
+		{entities.RoundingPolicy{Increment: 15}, 8, 15},
+		{entities.RoundingPolicy{Increment: 15, Mode: entities.RoundUp}, 31, 45},
+		{entities.RoundingPolicy{Increment: 15, Mode: entities.RoundUp}, 30, 30},
+		{entities.RoundingPolicy{Increment: 30, Mode: entities.RoundDown}, 59, 30},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.Round(tt.minutes); got != tt.expected {
+			t.Errorf("Expected %+v to round %d minutes to %d, got %d", tt.policy, tt.minutes, tt.expected, got)
+		}
+	}
+}
+
+func TestParseRoundingPolicy(t *testing.T) {
+	policy, err := entities.ParseRoundingPolicy("30/up/day")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if policy.Increment != 30 || policy.Mode != entities.RoundUp || policy.Scope != entities.RoundPerDay {
+		t.Errorf("Expected 30 minutes rounded up per day, got %+v", policy)
+	}
+
+	policy, err = entities.ParseRoundingPolicy("15")
+	if err != nil || policy.Mode != entities.RoundNearest || policy.Scope != entities.RoundPerEntry {
+		t.Errorf("Expected nearest per entry by default, got %+v, %v", policy, err)
+	}
+
+	for _, invalid := range []string{"", "abc", "-15", "15/sideways", "15/up/week", "15/up/day/extra"} {
+		if _, err := entities.ParseRoundingPolicy(invalid); err == nil {
+			t.Errorf("Expected error for %q, got nil", invalid)
+		}
+	}
+}
+
+func TestReportBillableMinutes(t *testing.T) {
+	day1 := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, time.September, 2, 22, 0, 0, 0, time.UTC)
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.example/OPS-1", Minutes: 20, Date: day1})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.example/OPS-2", Minutes: 25, Date: day1})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.example/OPS-3", Minutes: 10, Date: day2})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://other.example/T-1", Minutes: 5, Date: day1})
+
+	// Raw minutes are billed as is until a policy is set
+	if report.BillableTime != 60 || report.Entries[0].BillableMinutes != 20 {
+		t.Fatalf("Expected raw billable minutes, got %d", report.BillableTime)
+	}
+
+	report.SetRounding(entities.RoundingPolicies{
+		Default: entities.RoundingPolicy{Increment: 15, Mode: entities.RoundUp},
+		Rules: []entities.RoundingRule{
+			{Prefix: "https://acme.example/", Policy: entities.RoundingPolicy{Increment: 30, Mode: entities.RoundUp, Scope: entities.RoundPerDay}},
+		},
+	})
+
+	// Day 1 of acme bills 45 minutes as 60, added to its last entry; day 2 bills 10 as 30.
+	// The other client rounds each entry up to 15.
+	expected := []int{20, 40, 30, 15}
+	for i, minutes := range expected {
+		if report.Entries[i].BillableMinutes != minutes {
+			t.Errorf("Expected entry %d to bill %d minutes, got %d", i, minutes, report.Entries[i].BillableMinutes)
+		}
+	}
+	if report.TotalTime != 60 || report.BillableTime != 105 {
+		t.Errorf("Expected 60 raw and 105 billable minutes, got %d and %d", report.TotalTime, report.BillableTime)
+	}
+}
+
+func TestReportBillableMinutesByClientAndProject(t *testing.T) {
+	day := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-1", Minutes: 35, Date: day,
+		Ticket: entities.TicketInfo{Key: "OPS-1", Project: "Operations", Client: "Acme"}})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/GLX-1", Minutes: 35, Date: day,
+		Ticket: entities.TicketInfo{Key: "GLX-1", Project: "Globex", Client: "Globex"}})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/INFRA-2", Minutes: 35, Date: day})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-2", Minutes: 35, Date: day,
+		Ticket: entities.TicketInfo{Key: "OPS-2", Project: "Infra", Client: "Acme"}})
+
+	report.SetRounding(entities.RoundingPolicies{
+		Rules: []entities.RoundingRule{
+			{Client: "acme", Policy: entities.RoundingPolicy{Increment: 15, Mode: entities.RoundUp}},
+			{Project: "INFRA", Policy: entities.RoundingPolicy{Increment: 20, Mode: entities.RoundUp}},
+			{Prefix: "https://acme.atlassian.net/", Policy: entities.RoundingPolicy{Increment: 30, Mode: entities.RoundUp}},
+		},
+	})
+
+	// The client rule selects Acme, the URL prefix is only the fallback of other clients,
+	// and project rules match the project name or the key of the URL before the client
+	expected := []int{45, 60, 40, 40}
+	for i, minutes := range expected {
+		if report.Entries[i].BillableMinutes != minutes {
+			t.Errorf("Expected entry %d to bill %d minutes, got %d", i, minutes, report.Entries[i].BillableMinutes)
+		}
+	}
+}
+
+func TestReportBillableMinutesRoundDownPerDay(t *testing.T) {
+	day := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.SetRounding(entities.RoundingPolicies{
+		Default: entities.RoundingPolicy{Increment: 30, Mode: entities.RoundDown, Scope: entities.RoundPerDay},
+	})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 35, Date: day})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 10, Date: day})
+
+	// 45 minutes round down to 30; no entry bills negative minutes
+	if report.Entries[0].BillableMinutes != 30 || report.Entries[1].BillableMinutes != 0 || report.BillableTime != 30 {
+		t.Errorf("Expected 30 and 0 billable minutes, got %+v", report.Entries)
+	}
+}
+
+func TestOvertimeLimitsCheck(t *testing.T) {
+	day1 := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, time.September, 2, 22, 0, 0, 0, time.UTC)
+	entries := []entities.OvertimeEntry{
+		{TicketURL: "http://jira.com/ticket1", Minutes: 90, Owner: "Ana", Date: day1},
+		{TicketURL: "http://jira.com/ticket2", Minutes: 60, Owner: "ana", Date: day1},
+		{TicketURL: "http://jira.com/ticket3", Minutes: 120, Owner: "Ana", Date: day2},
+		{TicketURL: "http://jira.com/ticket4", Minutes: 100, Owner: "bob", Date: day2},
+	}
+
+	if violations := (entities.OvertimeLimits{}).Check("Sep-2026", entries); violations != nil {
+		t.Errorf("Expected no violations without limits, got %+v", violations)
+	}
+
+	violations := entities.OvertimeLimits{DailyMinutes: 120, MonthlyMinutes: 240}.Check("Sep-2026", entries)
+	if len(violations) != 2 {
+		t.Fatalf("Expected a daily and a monthly violation, got %+v", violations)
+	}
+
+	// Owners match case-insensitively; a day at the limit is allowed
+	daily := violations[0]
+	if daily.Kind != entities.ViolationDaily || daily.Owner != "Ana" || !daily.Date.Equal(time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)) || daily.Excess() != 30 {
+		t.Errorf("Expected Ana's 150 minutes on Sep 1, got %+v", daily)
+	}
+	monthly := violations[1]
+	if monthly.Kind != entities.ViolationMonthly || monthly.Minutes != 270 || monthly.Period != "Sep-2026" {
+		t.Errorf("Expected Ana's 270 minutes in Sep-2026, got %+v", monthly)
+	}
+}
+
+func TestReportApplyApprovals(t *testing.T) {
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 60, Source: "overtime-1"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 30, Source: "overtime-2"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket3", Minutes: 45, Source: "overtime-3"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket4", Minutes: 15, Source: "overtime-4"})
+	// Entries merged before sources were recorded cannot be decided on
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket5", Minutes: 20})
+
+	report.ApplyApprovals(map[string]entities.Approval{
+		"overtime-1": {Status: entities.StatusApproved, Approver: "manager"},
+		"overtime-2": {Status: entities.StatusRejected, Approver: "manager", Reason: "duplicated"},
+		"overtime-3": {},
+	})
+
+	if len(report.Entries) != 2 || report.Entries[0].Source != "overtime-1" || report.Entries[0].Approval.Approver != "manager" {
+		t.Fatalf("Expected the approved entry and the legacy entry, got %+v", report.Entries)
+	}
+	if report.Entries[1].Source != "" || report.Entries[1].Approval.Status != entities.StatusApproved {
+		t.Errorf("Expected the entry without source approved, got %+v", report.Entries[1])
+	}
+	if report.TotalTime != 80 {
+		t.Errorf("Expected total of the approved entries, got %d", report.TotalTime)
+	}
+
+	// Undecided entries and entries of unknown sources are pending; rejected ones are dropped
+	if len(report.Pending) != 2 || report.PendingMinutes() != 60 {
+		t.Errorf("Expected two pending entries with 60 minutes, got %+v", report.Pending)
+	}
+	for _, entry := range report.Pending {
+		if entry.Approval.Status != entities.StatusPending {
+			t.Errorf("Expected pending status, got %q", entry.Approval.Status)
+		}
+	}
+}
+
+func TestParseEntryStatus(t *testing.T) {
+	tests := map[string]entities.EntryStatus{
+		"approved": entities.StatusApproved,
+		"Rejected": entities.StatusRejected,
+		"":         entities.StatusPending,
+		"unknown":  entities.StatusPending,
+	}
+	for value, expected := range tests {
+		if status := entities.ParseEntryStatus(value); status != expected {
+			t.Errorf("ParseEntryStatus(%q): expected %q, got %q", value, expected, status)
+		}
+	}
+}
+
+func TestEntryRulesValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry entities.OvertimeEntry
+		valid bool
+	}{
+		{"valid", entities.OvertimeEntry{TicketURL: "https://jira.com/browse/OPS-1", Minutes: 90}, true},
+		{"empty ticket", entities.OvertimeEntry{TicketURL: " ", Minutes: 90}, false},
+		{"ticket without scheme", entities.OvertimeEntry{TicketURL: "jira.com/browse/OPS-1", Minutes: 90}, false},
+		{"zero minutes", entities.OvertimeEntry{TicketURL: "https://jira.com/browse/OPS-1"}, false},
+		{"negative minutes", entities.OvertimeEntry{TicketURL: "https://jira.com/browse/OPS-1", Minutes: -10}, false},
+		{"longer than a day", entities.OvertimeEntry{TicketURL: "https://jira.com/browse/OPS-1", Minutes: 24*60 + 1}, false},
+	}
+
+	for _, tt := range tests {
+		err := entities.EntryRules{}.Validate(tt.entry)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid %v, got error %v", tt.name, tt.valid, err)
+		}
+	}
+
+	// The maximum is configurable
+	if err := (entities.EntryRules{MaxMinutes: 600}).Validate(entities.OvertimeEntry{TicketURL: "https://jira.com/browse/OPS-1", Minutes: 601}); err == nil {
+		t.Error("Expected entries above the configured maximum to be invalid")
+	}
+}
+
+func TestParseTicketRef(t *testing.T) {
+	tests := []struct {
+		url       string
+		provider  entities.TicketProvider
+		project   string
+		key       string
+		canonical string
+	}{
+		{"https://acme.atlassian.net/browse/OPS-123/", entities.ProviderJira, "OPS", "OPS-123", "https://acme.atlassian.net/browse/OPS-123"},
+		{"https://ACME.atlassian.net/jira/software/projects/OPS/boards/1?selectedIssue=OPS-7", entities.ProviderJira, "OPS", "OPS-7", "https://acme.atlassian.net/browse/OPS-7"},
+		{"https://intranet.example.com/jira/browse/INFRA-9?focusedCommentId=1#comment", entities.ProviderJira, "INFRA", "INFRA-9", "https://intranet.example.com/jira/browse/INFRA-9"},
+		{"https://github.com/Acme/API/issues/12?q=1", entities.ProviderGitHub, "acme/api", "acme/api#12", "https://github.com/acme/api/issues/12"},
+		{"https://github.com/acme/api/pull/15/files", entities.ProviderGitHub, "acme/api", "acme/api#15", "https://github.com/acme/api/pull/15"},
+		{"https://gitlab.com/acme/platform/api/-/issues/4", entities.ProviderGitLab, "acme/platform/api", "acme/platform/api#4", "https://gitlab.com/acme/platform/api/-/issues/4"},
+		{"https://gitlab.acme.com/ops/infra/issues/4/", entities.ProviderGitLab, "ops/infra", "ops/infra#4", "https://gitlab.acme.com/ops/infra/-/issues/4"},
+		{"https://gitlab.com/acme/api/-/merge_requests/8/diffs", entities.ProviderGitLab, "acme/api", "acme/api!8", "https://gitlab.com/acme/api/-/merge_requests/8"},
+		{"https://dev.azure.com/acme/Billing/_workitems/edit/321", entities.ProviderAzureDevOps, "acme/Billing", "acme/Billing#321", "https://dev.azure.com/acme/Billing/_workitems/edit/321"},
+		{"https://acme.visualstudio.com/Billing/_workitems/edit/321/", entities.ProviderAzureDevOps, "acme/Billing", "acme/Billing#321", "https://dev.azure.com/acme/Billing/_workitems/edit/321"},
+		{"HTTP://Intranet/Incident/?id=3#top", entities.ProviderUnknown, "", "", "http://intranet/Incident?id=3"},
+	}
+
+	for _, tt := range tests {
+		ref := entities.ParseTicketRef(tt.url)
+		if ref.Provider != tt.provider || ref.Project != tt.project || ref.Key != tt.key || ref.URL != tt.canonical {
+			t.Errorf("ParseTicketRef(%q): got %+v", tt.url, ref)
+		}
+	}
+
+	// Keys in the paths of other sites are not Jira tickets
+	for _, other := range []string{
+		"https://linear.app/acme/issue/ENG-123/fix-login",
+		"https://acme.atlassian.net/wiki/spaces/ENG/pages/42/RFC-42",
+		"https://example.com/releases/V2-10",
+	} {
+		if ref := entities.ParseTicketRef(other, "https://acme.atlassian.net"); ref.Provider != entities.ProviderUnknown || ref.Key != "" {
+			t.Errorf("ParseTicketRef(%q): expected an unknown tracker, got %+v", other, ref)
+		}
+	}
+
+	// Context paths of Jira Server are kept, and the configured host recognizes project issue paths
+	jiraTests := []struct {
+		url       string
+		canonical string
+	}{
+		{"https://jira.acme.com/jira/browse/INFRA-1", "https://jira.acme.com/jira/browse/INFRA-1"},
+		{"https://jira.acme.com/jira/secure/RapidBoard.jspa?rapidView=3&selectedIssue=INFRA-2", "https://jira.acme.com/jira/browse/INFRA-2"},
+		{"https://jira.acme.com/jira/projects/INFRA/issues/INFRA-3", "https://jira.acme.com/jira/browse/INFRA-3"},
+	}
+	for _, tt := range jiraTests {
+		if ref := entities.ParseTicketRef(tt.url, "https://jira.acme.com/jira/"); ref.Provider != entities.ProviderJira || ref.URL != tt.canonical {
+			t.Errorf("ParseTicketRef(%q): got %+v", tt.url, ref)
+		}
+	}
+	if ref := entities.ParseTicketRef("https://jira.acme.com/jira/projects/INFRA/issues/INFRA-3"); ref.Provider != entities.ProviderUnknown {
+		t.Errorf("Expected project issue paths recognized on the Jira host only, got %+v", ref)
+	}
+
+	// Tickets without a key are grouped by their normalized URL
+	if key := entities.ParseTicketRef("http://intranet/incident/").GroupKey(); key != "http://intranet/incident" {
+		t.Errorf("Expected the normalized URL as group key, got %q", key)
+	}
+}
+
+func TestReportNormalizeTickets(t *testing.T) {
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-1/", Minutes: 30})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-1?filter=2", Minutes: 45})
+
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://linear.app/acme/issue/ENG-123/fix-login/", Minutes: 15})
+
+	report.NormalizeTickets("")
+
+	for _, entry := range report.Entries[:2] {
+		if entry.TicketURL != "https://acme.atlassian.net/browse/OPS-1" {
+			t.Errorf("Expected the canonical URL, got %q", entry.TicketURL)
+		}
+	}
+	if url := report.Entries[2].TicketURL; url != "https://linear.app/acme/issue/ENG-123/fix-login/" {
+		t.Errorf("Expected links of unknown trackers untouched, got %q", url)
+	}
+}
+
+func TestReportAggregations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.September, d, 20, 0, 0, 0, time.UTC) }
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-1", Minutes: 30, Date: day(1), Owner: "Ana"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://github.com/acme/api/issues/7", Minutes: 120, Date: day(2), Owner: "bruno"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-1/", Minutes: 45, Date: day(8), Owner: "ana"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-2", Minutes: 60, Date: day(1), Owner: "ana"})
+
+	tickets := report.ByTicket()
+	if len(tickets) != 3 {
+		t.Fatalf("Expected 3 tickets, got %d", len(tickets))
+	}
+	if tickets[0].Key != "acme/api#7" || tickets[1].Key != "OPS-1" || tickets[1].Minutes != 75 || tickets[1].Count() != 2 {
+		t.Errorf("Expected tickets ordered by minutes with OPS-1 summed, got %+v", tickets)
+	}
+	if tickets[1].Label != "https://acme.atlassian.net/browse/OPS-1" {
+		t.Errorf("Expected the canonical URL as label, got %q", tickets[1].Label)
+	}
+
+	projects := report.ByProject()
+	if len(projects) != 2 || projects[0].Label != "OPS" || projects[0].Minutes != 135 || len(projects[0].ByTicket()) != 2 {
+		t.Errorf("Expected OPS first with 135 minutes on 2 tickets, got %+v", projects)
+	}
+
+	owners := report.ByOwner()
+	if len(owners) != 2 || owners[0].Key != "ana" || owners[0].Label != "Ana" || owners[0].Minutes != 135 {
+		t.Errorf("Expected owners grouped ignoring case, got %+v", owners)
+	}
+
+	days := report.ByDay()
+	if len(days) != 3 || days[0].Key != "2026-09-01" || days[0].Minutes != 90 || days[2].Key != "2026-09-08" {
+		t.Errorf("Expected days in chronological order, got %+v", days)
+	}
+
+	weeks := report.ByWeek()
+	if len(weeks) != 2 || weeks[0].Key != "2026-W36" || weeks[0].Minutes != 210 || weeks[1].Key != "2026-W37" {
+		t.Errorf("Expected ISO weeks, got %+v", weeks)
+	}
+	if !weeks[1].Start.Equal(time.Date(2026, time.September, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected weeks to start on Monday, got %v", weeks[1].Start)
+	}
+}
+
+func TestWorkingHoursMinutesOutside(t *testing.T) {
+	hours, err := entities.ParseWorkingHours("09:00-18:00", "mon-fri", "")
+	if err != nil {
+		t.Fatalf("Error parsing working hours: %v", err)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.September, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   int
+	}{
+		{"within hours", at(1, 10, 0), at(1, 12, 0), 0},
+		{"evening", at(1, 17, 0), at(1, 19, 30), 90},
+		{"overnight", at(1, 22, 0), at(2, 9, 30), 660},
+		{"weekend", at(5, 10, 0), at(5, 12, 0), 120},
+	}
+	for _, tt := range tests {
+		if got := hours.MinutesOutside(tt.start, tt.end); got != tt.expected {
+			t.Errorf("%s: expected %d minutes outside, got %d", tt.name, tt.expected, got)
+		}
+	}
+
+	if _, err := entities.ParseWorkingHours("18:00-09:00", "", ""); err == nil {
+		t.Error("Expected an error for working hours ending before they start")
+	}
+	if _, err := entities.ParseWorkingHours("", "mon-funday", ""); err == nil {
+		t.Error("Expected an error for an unknown working day")
+	}
+}
+
+func TestImportRulesConvert(t *testing.T) {
+	rules := entities.ImportRules{
+		Tickets:      map[string]string{"acme api": "https://github.com/acme/api/issues/7"},
+		OvertimeTags: []string{"overtime"},
+		WorkingHours: entities.DefaultWorkingHours,
+		JiraBaseURL:  "https://acme.atlassian.net/",
+		Owner:        "ops@example.com",
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.September, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	result := rules.Convert([]entities.TrackedEntry{
+		{Start: at(10, 0), End: at(11, 0), Project: "Internal", Tags: []string{"Overtime"}, Description: "Fix OPS-3", Line: 1},
+		{Start: at(17, 0), End: at(19, 0), Project: "Acme API", User: "ana@example.com", Line: 2},
+		{Start: at(10, 0), End: at(12, 0), Project: "Acme API", Line: 3},
+		{Start: at(20, 0), End: at(21, 0), Project: "Unmapped", Line: 4},
+		{Start: at(20, 0), End: at(21, 0), Description: "See https://gitlab.com/acme/web/-/issues/4.", Line: 5},
+	})
+
+	if len(result.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", result.Entries)
+	}
+	if result.Entries[0].TicketURL != "https://acme.atlassian.net/browse/OPS-3" || result.Entries[0].Minutes != 60 || result.Entries[0].Owner != "ops@example.com" {
+		t.Errorf("Expected the tagged entry in full on its Jira key, got %+v", result.Entries[0])
+	}
+	if result.Entries[1].TicketURL != "https://github.com/acme/api/issues/7" || result.Entries[1].Minutes != 60 || result.Entries[1].Owner != "ana@example.com" {
+		t.Errorf("Expected the hour after 18:00 on the mapped project, got %+v", result.Entries[1])
+	}
+	if result.Entries[2].TicketURL != "https://gitlab.com/acme/web/-/issues/4" {
+		t.Errorf("Expected the link of the description, got %q", result.Entries[2].TicketURL)
+	}
+	if len(result.Skipped) != 2 || result.Skipped[0].Entry.Line != 3 || result.Skipped[1].Entry.Line != 4 {
+		t.Errorf("Expected the working hours and unmapped entries skipped, got %+v", result.Skipped)
+	}
+}
+
+func TestSuggestRulesSessions(t *testing.T) {
+	rules := entities.SuggestRules{WorkingHours: entities.DefaultWorkingHours, JiraBaseURL: "https://acme.atlassian.net"}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.September, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	sessions := rules.Sessions([]entities.Commit{
+		{Repository: "api", Time: at(1, 21, 0), Message: "Fix failover OPS-7"},
+		{Repository: "api", Time: at(1, 11, 0), Message: "During working hours"},
+		{Repository: "web", Time: at(1, 20, 0), Message: "Start hotfix"},
+		{Repository: "api", Time: at(1, 22, 30), Message: "See https://github.com/acme/api/issues/9"},
+		{Repository: "api", Time: at(5, 10, 0), Message: "Weekend deploy"},
+	})
+
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %+v", sessions)
+	}
+	first := sessions[0]
+	if len(first.Commits) != 3 || first.Minutes != 180 || first.Description() != "3 commits in web, api" {
+		t.Errorf("Expected 180 minutes from 19:30 to 22:30 over 3 commits, got %d minutes and %q", first.Minutes, first.Description())
+	}
+	if len(first.Tickets) != 2 || first.Tickets[0] != "https://acme.atlassian.net/browse/OPS-7" || first.Tickets[1] != "https://github.com/acme/api/issues/9" {
+		t.Errorf("Expected the Jira key and the GitHub link, got %v", first.Tickets)
+	}
+	if sessions[1].Minutes != 30 {
+		t.Errorf("Expected the lead time of a single weekend commit, got %d", sessions[1].Minutes)
+	}
+
+	entries := rules.Entries(sessions, "ana@example.com")
+	if len(entries) != 3 || entries[0].Minutes != 90 || entries[1].Minutes != 90 || entries[2].TicketURL != "" {
+		t.Errorf("Expected the session split between its tickets and a proposal without ticket, got %+v", entries)
+	}
+}
+
+func TestCompareDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.September, d, 0, 0, 0, 0, time.UTC) }
+	suggested := []entities.OvertimeEntry{{Minutes: 90, Date: day(1)}, {Minutes: 30, Date: day(5)}}
+	logged := []entities.OvertimeEntry{{Minutes: 60, Date: day(1)}, {Minutes: 45, Date: day(3)}}
+
+	days := entities.CompareDays(suggested, logged)
+	if len(days) != 3 {
+		t.Fatalf("Expected 3 days, got %+v", days)
+	}
+	if days[0].Difference() != 30 || days[1].Suggested != 0 || days[1].Logged != 45 || days[2].Difference() != 30 {
+		t.Errorf("Unexpected comparison %+v", days)
+	}
+}
@@ -0,0 +1,798 @@
+package unit
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/adapters/exporters"
+	"github.com/MateSousa/overtime-script/pkg/adapters/repositories"
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExporterRegistryFormats(t *testing.T) {
+	registry := exporters.NewDefaultExporterRegistry()
+
+	formats := registry.Formats()
+	var names []string
+	for _, format := range formats {
+		names = append(names, format.Name)
+	}
+	if strings.Join(names, ",") != "xlsx,csv,json,ndjson,ods" {
+		t.Fatalf("Expected xlsx, csv, json, ndjson and ods formats, got %+v", formats)
+	}
+
+	format, err := registry.Format("csv")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if format.Extension != ".csv" || format.MIMEType != "text/csv" {
+		t.Errorf("Unexpected csv metadata %+v", format)
+	}
+
+	if _, err := registry.Format("doc"); err == nil {
+		t.Error("Expected error for an unknown format, got nil")
+	}
+
+	if err := registry.Export(context.Background(), newTestReport(), "doc", &bytes.Buffer{}); err == nil {
+		t.Error("Expected error exporting an unknown format, got nil")
+	}
+}
+
+func TestCSVExport(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	if err := registry.Export(context.Background(), newTestReport(), "csv", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "TICKET;MINUTOS\nhttp://jira.com/ticket1;90\nhttp://jira.com/ticket2;30\nTOTAL;120\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVExportOptions(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewCSVReportExporter(exporters.CSVOptions{
+		Delimiter: ',',
+		Language:  "en",
+		Headers:   map[string]string{exporters.CSVColumnTicket: "Issue"},
+		Columns: []string{
+			exporters.CSVColumnDate,
+			exporters.CSVColumnTicket,
+			exporters.CSVColumnHoursDecimal,
+			exporters.CSVColumnHoursMinutes,
+		},
+		DecimalSeparator: ".",
+		BOM:              true,
+	})
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{
+		TicketURL: "http://jira.com/ticket1",
+		Minutes:   90,
+		Date:      time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "\ufeffDATE,Issue,HOURS,HH:MM\n2026-09-01,http://jira.com/ticket1,1.50,01:30\nTOTAL,,1.50,01:30\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVExportWithoutTotal(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewCSVReportExporter(exporters.CSVOptions{
+		Columns:   []string{exporters.CSVColumnMinutes, exporters.CSVColumnHoursDecimal},
+		OmitTotal: true,
+	})
+
+	if err := exporter.Export(context.Background(), newTestReport(), &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "MINUTOS;HORAS\n90;1,50\n30;0,50\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVExportDurationFormat(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewCSVReportExporter(exporters.CSVOptions{
+		Columns:  []string{exporters.CSVColumnTicket, exporters.CSVColumnDuration, exporters.CSVColumnHoursDecimal},
+		Duration: entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundDown, Precision: 1},
+	})
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 50})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "TICKET;DURAÇÃO;HORAS\nhttp://jira.com/ticket1;0,8;0,8\nTOTAL;0,8;0,8\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVExportBillableMinutes(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewCSVReportExporter(exporters.CSVOptions{
+		Columns: []string{exporters.CSVColumnTicket, exporters.CSVColumnMinutes, exporters.CSVColumnBillable},
+	})
+
+	report := newTestReport()
+	report.SetRounding(entities.RoundingPolicies{Default: entities.RoundingPolicy{Increment: 60, Mode: entities.RoundUp}})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "TICKET;MINUTOS;MINUTOS FATURÁVEIS\nhttp://jira.com/ticket1;90;120\nhttp://jira.com/ticket2;30;60\nTOTAL;120;180\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVExportTicketColumns(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewCSVReportExporter(exporters.CSVOptions{
+		Language:  "en",
+		Columns:   []string{exporters.CSVColumnTicketKey, exporters.CSVColumnTicketTitle, exporters.CSVColumnClient, exporters.CSVColumnMinutes},
+		OmitTotal: true,
+	})
+
+	report := newTestReport()
+	report.Entries[0].Ticket = entities.TicketInfo{Key: "OPS-12", Title: "Database failover", Project: "Operations", Client: "Acme"}
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "KEY;TITLE;CLIENT;MINUTES\nOPS-12;Database failover;Acme;90\n;;;30\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVOptionsValidate(t *testing.T) {
+	if err := (exporters.CSVOptions{Columns: []string{"ticket", "cost"}}).Validate(); err == nil {
+		t.Error("Expected error for an unknown column, got nil")
+	}
+
+	if err := (exporters.CSVOptions{Language: "fr"}).Validate(); err == nil {
+		t.Error("Expected error for an unsupported language, got nil")
+	}
+
+	if err := (exporters.CSVOptions{Language: "en", Columns: []string{"date", "owner"}}).Validate(); err != nil {
+		t.Errorf("Expected valid options, got %v", err)
+	}
+}
+
+func TestJSONExport(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	report := newTestReport()
+	report.AppendEntry(entities.OvertimeEntry{
+		TicketURL:   "http://jira.com/ticket3",
+		Minutes:     60,
+		Date:        time.Date(2026, time.September, 5, 0, 0, 0, 0, time.UTC),
+		Description: "Holiday deploy",
+		Multiplier:  2,
+	})
+
+	if err := registry.Export(context.Background(), report, "json", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var document exporters.JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("Error decoding JSON report: %v", err)
+	}
+
+	if document.SchemaVersion != exporters.ReportSchemaVersion || document.Period != "Sep-2026" || document.PeriodStart != "2026-09-01" {
+		t.Errorf("Unexpected report metadata %+v", document)
+	}
+
+	if document.TotalMinutes != 180 || document.TotalHours != "03:00" || document.TotalHoursDecimal != 3 || document.WeightedMinutes != 240 || document.EntryCount != 3 {
+		t.Errorf("Unexpected report totals %+v", document)
+	}
+
+	last := document.Entries[2]
+	if last.Date != "2026-09-05" || last.Description != "Holiday deploy" || last.Multiplier != 2 {
+		t.Errorf("Unexpected entry %+v", last)
+	}
+	if len(document.Tickets) != 3 || document.Tickets[0].Key != "http://jira.com/ticket1" || document.Tickets[1].Key != "http://jira.com/ticket3" || document.Tickets[1].Minutes != 60 {
+		t.Errorf("Expected ticket subtotals, got %+v", document.Tickets)
+	}
+
+	// The schema field names are part of the contract with downstream systems
+	for _, field := range []string{`"schema_version"`, `"total_minutes"`, `"entries"`, `"ticket"`, `"minutes"`} {
+		if !strings.Contains(buf.String(), field) {
+			t.Errorf("Expected JSON to contain field %s", field)
+		}
+	}
+}
+
+func TestExcelAndJSONExportPendingEntries(t *testing.T) {
+	registry := exporters.NewDefaultExporterRegistry()
+
+	report := newTestReport()
+	report.Pending = []entities.OvertimeEntry{
+		{TicketURL: "http://jira.com/ticket3", Minutes: 45, Date: time.Date(2026, time.September, 5, 0, 0, 0, 0, time.UTC), Source: "overtime-3"},
+	}
+
+	var xlsx bytes.Buffer
+	if err := registry.Export(context.Background(), report, "xlsx", &xlsx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f, err := excelize.OpenReader(&xlsx)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	for cell, expected := range map[string]string{"B2": "http://jira.com/ticket3", "F2": "overtime-3", "D3": "45"} {
+		value, err := f.CalcCellValue(exporters.PendingSheet, cell)
+		if err != nil {
+			t.Fatalf("Error calculating %s: %v", cell, err)
+		}
+		if value != expected {
+			t.Errorf("Expected %s to be %q, got %q", cell, expected, value)
+		}
+	}
+
+	// Pending entries stay out of the totals
+	total, err := f.CalcCellValue(exporters.EntriesSheet, "D4")
+	if err != nil || total != "120" {
+		t.Errorf("Expected entries total of 120, got %q (%v)", total, err)
+	}
+
+	var buf bytes.Buffer
+	if err := registry.Export(context.Background(), report, "json", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var document exporters.JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("Error decoding JSON report: %v", err)
+	}
+	if len(document.Pending) != 1 || document.Pending[0].Source != "overtime-3" || document.TotalMinutes != 120 {
+		t.Errorf("Expected one pending entry apart from the totals, got %+v", document)
+	}
+}
+
+func TestNDJSONExport(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	if err := registry.Export(context.Background(), newTestReport(), "ndjson", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per entry, got %d", len(lines))
+	}
+
+	var entry exporters.NDJSONEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("Error decoding NDJSON line: %v", err)
+	}
+
+	if entry.SchemaVersion != exporters.ReportSchemaVersion || entry.Period != "Sep-2026" || entry.Ticket != "http://jira.com/ticket2" || entry.Minutes != 30 {
+		t.Errorf("Unexpected NDJSON entry %+v", entry)
+	}
+}
+
+func TestODSExport(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	if err := registry.Export(context.Background(), newTestReport(), "ods", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	content := readODSContent(t, buf.Bytes())
+
+	for _, expected := range []string{
+		`table:name="Resumo"`,
+		`table:name="Lançamentos"`,
+		`<text:p>http://jira.com/ticket1</text:p>`,
+		`table:formula="of:=SUM([.D2:.D3])" office:value-type="float" office:value="120"`,
+		`fo:background-color="#4472c4"`,
+		`table:formula="of:=[.B2]/1440" office:value-type="time" office:time-value="PT2H00M00S"`,
+		`table:use-regular-expressions="false" table:use-wildcards="false"`,
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected content.xml to contain %s", expected)
+		}
+	}
+}
+
+// readODSContent checks the layout of an exported spreadsheet and returns its well formed content.xml
+func readODSContent(t *testing.T, data []byte) []byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Error opening exported spreadsheet: %v", err)
+	}
+
+	// The mimetype entry comes first and uncompressed so the file is recognised
+	if archive.File[0].Name != "mimetype" || archive.File[0].Method != zip.Store {
+		t.Errorf("Expected an uncompressed mimetype first entry, got %s", archive.File[0].Name)
+	}
+
+	var content []byte
+	for _, file := range archive.File {
+		if file.Name == "content.xml" {
+			rc, err := file.Open()
+			if err != nil {
+				t.Fatalf("Error opening content.xml: %v", err)
+			}
+			content, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+
+	// The content must be well formed XML
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Expected well formed content.xml, got %v", err)
+		}
+	}
+	return content
+}
+
+func TestExcelExport(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	day1 := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, time.September, 2, 23, 0, 0, 0, time.UTC)
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 90, Date: day1, Description: "Deploy"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 30, Date: day1})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 60, Date: day2, Multiplier: 2})
+
+	if err := registry.Export(context.Background(), report, "xlsx", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	expectedSheets := []string{exporters.SummarySheet, exporters.DashboardSheet, exporters.DailySheet, exporters.TicketsSheet, exporters.ProjectsSheet, exporters.EntriesSheet}
+	if sheets := f.GetSheetList(); strings.Join(sheets, ",") != strings.Join(expectedSheets, ",") {
+		t.Fatalf("Expected sheets %v, got %v", expectedSheets, sheets)
+	}
+
+	// Raw entries keep their details
+	for cell, expected := range map[string]string{"B2": "http://jira.com/ticket1", "C2": "Deploy", "D4": "60"} {
+		value, err := f.GetCellValue(exporters.EntriesSheet, cell)
+		if err != nil {
+			t.Fatalf("Error reading cell %s: %v", cell, err)
+		}
+		if value != expected {
+			t.Errorf("Expected %s to be %q, got %q", cell, expected, value)
+		}
+	}
+
+	// Totals are formulas over the entries sheet
+	formula, _ := f.GetCellFormula(exporters.EntriesSheet, "D5")
+	if !strings.HasPrefix(formula, "SUM(") {
+		t.Errorf("Expected the entries total to be a SUM formula, got %q", formula)
+	}
+
+	calculated := map[string]map[string]string{
+		exporters.SummarySheet:  {"B2": "180", "B4": "3", "B5": "240", "B7": "180"},
+		exporters.DailySheet:    {"B2": "2", "C2": "120", "C3": "60", "C4": "180"},
+		exporters.TicketsSheet:  {"A2": "http://jira.com/ticket1", "C2": "150", "C3": "30", "C4": "180"},
+		exporters.ProjectsSheet: {"A2": "Sem projeto", "B2": "3", "C2": "180", "D2": "2", "C3": "180"},
+	}
+	for sheet, cells := range calculated {
+		for cell, expected := range cells {
+			value, err := f.CalcCellValue(sheet, cell)
+			if err != nil {
+				t.Fatalf("Error calculating %s!%s: %v", sheet, cell, err)
+			}
+			if value != expected {
+				t.Errorf("Expected %s!%s to be %q, got %q", sheet, cell, expected, value)
+			}
+		}
+	}
+
+	// Hours stay numeric, as fractions of a day displayed as durations
+	for cell, expected := range map[string]string{"B3": "0.125", "B6": "0.166666666666667"} {
+		value, err := f.CalcCellValue(exporters.SummarySheet, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			t.Fatalf("Error calculating %s: %v", cell, err)
+		}
+		if value != expected {
+			t.Errorf("Expected %s to be %q, got %q", cell, expected, value)
+		}
+	}
+	assertNumberFormat(t, f, exporters.SummarySheet, "B3", "[hh]:mm")
+}
+
+func TestExcelExportGroupsTicketSpellings(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	day := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.UTC)
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1/", Minutes: 90, Date: day})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "HTTP://Jira.com/ticket1", Minutes: 30, Date: day})
+
+	if err := registry.Export(context.Background(), report, "xlsx", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	// Both spellings are one ticket, whatever the URLs of the entries sheet
+	for cell, expected := range map[string]string{"A2": "http://jira.com/ticket1", "B2": "2", "C2": "120", "A3": "TOTAL", "C3": "120"} {
+		value, err := f.CalcCellValue(exporters.TicketsSheet, cell)
+		if err != nil {
+			t.Fatalf("Error calculating %s: %v", cell, err)
+		}
+		if value != expected {
+			t.Errorf("Expected %s!%s to be %q, got %q", exporters.TicketsSheet, cell, expected, value)
+		}
+	}
+}
+
+func TestExcelExportDecimalHours(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewExcelReportExporter(exporters.ExcelOptions{
+		Duration: entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundUp, Precision: 1},
+	})
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 50, Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 30, Date: time.Date(2026, time.September, 2, 0, 0, 0, 0, time.UTC)})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	// Each entry is rounded up on its own, the summary rounds the total
+	expected := map[string]map[string]string{
+		exporters.EntriesSheet: {"I1": "HORAS", "I2": "0.9", "I3": "0.5", "I4": "1.4"},
+		exporters.SummarySheet: {"A3": "Total de horas (decimal)", "B3": "1.4"},
+	}
+	for sheet, cells := range expected {
+		for cell, want := range cells {
+			value, err := f.CalcCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				value, err = f.GetCellValue(sheet, cell)
+			}
+			if err != nil {
+				t.Fatalf("Error reading %s!%s: %v", sheet, cell, err)
+			}
+			if value != want {
+				t.Errorf("Expected %s!%s to be %q, got %q", sheet, cell, want, value)
+			}
+		}
+	}
+	assertNumberFormat(t, f, exporters.EntriesSheet, "I2", "0.0")
+}
+
+func TestODSExportDecimalHours(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewODSReportExporter(exporters.ODSOptions{
+		Duration: entities.DurationFormat{Unit: entities.DurationDecimalHours, Rounding: entities.RoundUp, Precision: 1},
+	})
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 50, Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 30, Date: time.Date(2026, time.September, 2, 0, 0, 0, 0, time.UTC)})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content := readODSContent(t, buf.Bytes())
+
+	// Each entry is rounded up on its own, the summary rounds the total
+	for _, expected := range []string{
+		`<text:p>HORAS</text:p>`,
+		`table:formula="of:=ROUNDUP([.D2]/60;1)" office:value-type="float" office:value="0.9"`,
+		`table:formula="of:=ROUNDUP([.D3]/60;1)" office:value-type="float" office:value="0.5"`,
+		`table:formula="of:=SUM([.I2:.I3])" office:value-type="float" office:value="1.4"`,
+		`<text:p>Total de horas (decimal)</text:p>`,
+		`table:formula="of:=ROUNDUP([.B2]/60;1)" office:value-type="float" office:value="1.4"`,
+		`<number:number number:decimal-places="1" number:min-integer-digits="1"/>`,
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected content.xml to contain %s", expected)
+		}
+	}
+}
+
+// assertNumberFormat checks the custom number format of a cell
+func assertNumberFormat(t *testing.T, f *excelize.File, sheet, cell, expected string) {
+	t.Helper()
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		t.Fatalf("Error reading style of %s!%s: %v", sheet, cell, err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("Error reading style %d: %v", styleID, err)
+	}
+	if style.CustomNumFmt == nil || *style.CustomNumFmt != expected {
+		t.Errorf("Expected %s!%s number format %q, got %v", sheet, cell, expected, style.CustomNumFmt)
+	}
+}
+
+func TestExcelExportViolations(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	report := newTestReport()
+	report.Violations = []entities.PolicyViolation{
+		{Kind: entities.ViolationDaily, Owner: "ana", Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), Minutes: 150, Limit: 120},
+		{Kind: entities.ViolationMonthly, Owner: "ana", Period: "Sep-2026", Minutes: 2500, Limit: 2400},
+	}
+
+	if err := registry.Export(context.Background(), report, "xlsx", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	for cell, expected := range map[string]string{"A2": "Diário", "A3": "Mensal", "C3": "Sep-2026", "F2": "30", "F3": "100"} {
+		value, err := f.CalcCellValue(exporters.ViolationsSheet, cell)
+		if err != nil {
+			t.Fatalf("Error calculating %s: %v", cell, err)
+		}
+		if value != expected {
+			t.Errorf("Expected %s to be %q, got %q", cell, expected, value)
+		}
+	}
+}
+
+func TestExcelExportDashboardCharts(t *testing.T) {
+	var buf bytes.Buffer
+	registry := exporters.NewDefaultExporterRegistry()
+
+	if err := registry.Export(context.Background(), newTestReport(), "xlsx", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+
+	charts := make(map[string]string)
+	for _, file := range archive.File {
+		if !strings.HasPrefix(file.Name, "xl/charts/chart") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Error opening %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		charts[file.Name] = string(content)
+	}
+
+	if len(charts) != 2 {
+		t.Fatalf("Expected 2 charts, got %d", len(charts))
+	}
+
+	// The daily chart plots the daily sheet and the ticket chart plots the tickets sheet
+	if !strings.Contains(charts["xl/charts/chart1.xml"], "Diário&#39;!$C$2:$C$2") {
+		t.Error("Expected the first chart to plot the daily minutes")
+	}
+	if !strings.Contains(charts["xl/charts/chart2.xml"], "Tickets&#39;!$C$2:$C$3") {
+		t.Error("Expected the second chart to plot the minutes per ticket")
+	}
+}
+
+func TestPDFExport(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewPDFReportExporter(exporters.PDFOptions{Company: "Darede", Employee: "Maria", Approver: "João"})
+
+	report := newTestReport()
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket3", Minutes: 60, Multiplier: 2})
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pdf := buf.String()
+	if !strings.HasPrefix(pdf, "%PDF-") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatal("Expected a complete PDF document")
+	}
+
+	for _, expected := range []string{"Sep-2026", "(Maria)", "(Aprova\xe7\xe3o)", "(Horas ponderadas)", "(240.0)", "/Count 1"} {
+		if !strings.Contains(pdf, expected) {
+			t.Errorf("Expected PDF to contain %q", expected)
+		}
+	}
+}
+
+func TestPDFExportDurationFormat(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewPDFReportExporter(exporters.PDFOptions{
+		Duration: entities.DurationFormat{Unit: entities.DurationHoursMinutes},
+	})
+
+	if err := exporter.Export(context.Background(), newTestReport(), &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, expected := range []string{"(HH:MM)", "(01:30)", "(00:30)", "(02:00)"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected PDF to contain %q", expected)
+		}
+	}
+}
+
+func TestPDFExportPaginates(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := exporters.NewPDFReportExporter(exporters.PDFOptions{})
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	for i := 0; i < 80; i++ {
+		report.AddEntry(fmt.Sprintf("http://jira.com/ticket%d", i), 30)
+	}
+
+	if err := exporter.Export(context.Background(), report, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "/Count 3") {
+		t.Error("Expected the entries to span three pages")
+	}
+
+	rows := regexp.MustCompile(`([0-9.]+) Td \((TOTAL|FATUR)`)
+	rounding := entities.RoundingPolicies{Default: entities.RoundingPolicy{Increment: 15}}
+
+	// 30 entries fill the first page exactly, so the totals start the second one
+	for count := 28; count <= 31; count++ {
+		var buf bytes.Buffer
+		report := entities.NewOvertimeReport("Sep-2026")
+		for i := 0; i < count; i++ {
+			report.AddEntry(fmt.Sprintf("http://jira.com/ticket%d", i), 20)
+		}
+		report.SetRounding(rounding)
+
+		if err := exporter.Export(context.Background(), report, &buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		matches := rows.FindAllStringSubmatch(buf.String(), -1)
+		if len(matches) != 2 {
+			t.Fatalf("Expected the total and billable rows with %d entries, got %v", count, matches)
+		}
+		for _, match := range matches {
+			// Text is drawn 5pt above the bottom of its row
+			if y, _ := strconv.ParseFloat(match[1], 64); y-5 < 150 {
+				t.Errorf("Expected the %s row above the signature block with %d entries, got y=%s", match[2], count, match[1])
+			}
+		}
+		if count >= 30 && !strings.Contains(buf.String(), "/Count 2") {
+			t.Errorf("Expected the totals of %d entries on a second page", count)
+		}
+	}
+}
+
+// writeTestTemplate writes a customer style template workbook and returns its path
+func writeTestTemplate(t *testing.T) string {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	f.SetCellValue(sheet, "A1", "Timesheet {{period}} - {{total_hours}}")
+	f.SetCellValue(sheet, "A3", "{{entry.date}}")
+	f.SetCellValue(sheet, "B3", "{{entry.ticket}}")
+	f.SetCellValue(sheet, "C3", "{{entry.minutes}}")
+	f.SetCellValue(sheet, "D3", "{{entry.duration}}")
+	f.SetCellValue(sheet, "A4", "Total")
+	f.SetCellValue(sheet, "C4", "{{total_minutes}}")
+	f.SetDefinedName(&excelize.DefinedName{Name: "entry_count", RefersTo: sheet + "!$E$1"})
+
+	path := filepath.Join(t.TempDir(), "template.xlsx")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("Error saving template: %v", err)
+	}
+	return path
+}
+
+func TestExcelTemplateExport(t *testing.T) {
+	var buf bytes.Buffer
+	templates := repositories.NewFileTemplateRepository(writeTestTemplate(t))
+	exporter := exporters.NewExcelTemplateExporter(templates, exporters.ExcelOptions{
+		Duration: entities.DurationFormat{Unit: entities.DurationDecimalHours, Precision: 2},
+	})
+
+	if err := exporter.Export(context.Background(), newTestReport(), &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Error opening exported workbook: %v", err)
+	}
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	expected := map[string]string{
+		"A1": "Timesheet Sep-2026 - 02:00",
+		"B3": "http://jira.com/ticket1",
+		"C3": "90",
+		"D3": "1.5",
+		"B4": "http://jira.com/ticket2",
+		"C4": "30",
+		"A5": "Total",
+		"C5": "120",
+		"E1": "2",
+	}
+	for cell, value := range expected {
+		got, err := f.GetCellValue(sheet, cell)
+		if err != nil {
+			t.Fatalf("Error reading cell %s: %v", cell, err)
+		}
+		if got != value {
+			t.Errorf("Expected %s to be %q, got %q", cell, value, got)
+		}
+	}
+
+	// Single placeholders keep their type
+	if cellType, _ := f.GetCellType(sheet, "C5"); cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Error("Expected the total to be written as a number")
+	}
+}
+
+func TestExcelTemplateExportMissingTemplate(t *testing.T) {
+	templates := repositories.NewFileTemplateRepository(filepath.Join(t.TempDir(), "missing.xlsx"))
+	exporter := exporters.NewExcelTemplateExporter(templates, exporters.ExcelOptions{})
+
+	if err := exporter.Export(context.Background(), newTestReport(), &bytes.Buffer{}); err == nil {
+		t.Error("Expected error for a missing template, got nil")
+	}
+}
@@ -0,0 +1,305 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/adapters/importers"
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+)
+
+func TestParseTogglCSV(t *testing.T) {
+	export := "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+		"Ana,ana@example.com,Acme,Acme API,,Deploy OPS-12,Yes,2026-09-01,19:30:00,2026-09-01,21:00:00,01:30:00,\"overtime, deploy\"\n"
+
+	tracked, _, err := importers.ParseTrackerCSV(strings.NewReader(export), importers.TrackerOptions{})
+	if err != nil {
+		t.Fatalf("Error parsing Toggl CSV: %v", err)
+	}
+	if len(tracked) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(tracked))
+	}
+
+	entry := tracked[0]
+	if entry.User != "ana@example.com" || entry.Project != "Acme API" || entry.Description != "Deploy OPS-12" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if !entry.Start.Equal(time.Date(2026, time.September, 1, 19, 30, 0, 0, time.UTC)) || entry.Minutes() != 90 {
+		t.Errorf("Expected 90 minutes from 19:30, got %v and %d minutes", entry.Start, entry.Minutes())
+	}
+	if len(entry.Tags) != 2 || entry.Tags[0] != "overtime" || entry.Tags[1] != "deploy" {
+		t.Errorf("Expected two tags, got %v", entry.Tags)
+	}
+}
+
+func TestParseClockifyCSV(t *testing.T) {
+	// Clockify exports of some locales use semicolons, a byte order mark and a 12 hour clock
+	export := "\ufeffProject;Client;Description;Task;User;Email;Tags;Billable;Start Date;Start Time;End Date;End Time;Duration (h)\n" +
+		"Acme API;Acme;Hotfix;;Bruno;bruno@example.com;;Yes;09/02/2026;11:00:00 PM;09/03/2026;12:30:00 AM;01:30:00\n"
+
+	location := time.FixedZone("BRT", -3*60*60)
+	tracked, _, err := importers.ParseTrackerCSV(strings.NewReader(export), importers.TrackerOptions{Location: location})
+	if err != nil {
+		t.Fatalf("Error parsing Clockify CSV: %v", err)
+	}
+	if len(tracked) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(tracked))
+	}
+	if !tracked[0].Start.Equal(time.Date(2026, time.September, 2, 23, 0, 0, 0, location)) || tracked[0].Minutes() != 90 {
+		t.Errorf("Expected 90 minutes from 23:00 on Sep 2, got %v and %d minutes", tracked[0].Start, tracked[0].Minutes())
+	}
+	if tracked[0].User != "bruno@example.com" {
+		t.Errorf("Expected the user email, got %q", tracked[0].User)
+	}
+}
+
+func TestParseTrackerJSON(t *testing.T) {
+	clockify := `{"timeentries": [{"description": "Hotfix", "userEmail": "ana@example.com", "projectName": "Acme API",
+		"tags": [{"name": "overtime"}], "timeInterval": {"start": "2026-09-05T10:00:00Z", "end": "2026-09-05T12:00:00Z"}}]}`
+	tracked, _, err := importers.ParseTrackerExport(strings.NewReader(clockify), "report.json", importers.TrackerOptions{})
+	if err != nil {
+		t.Fatalf("Error parsing Clockify JSON: %v", err)
+	}
+	if len(tracked) != 1 || tracked[0].Minutes() != 120 || tracked[0].Project != "Acme API" || len(tracked[0].Tags) != 1 {
+		t.Errorf("Unexpected Clockify entries %+v", tracked)
+	}
+
+	// Toggl time entries carry the duration in seconds
+	toggl := `[{"description": "Deploy", "start": "2026-09-05T20:00:00-03:00", "duration": 2700, "tags": ["overtime"]},
+		{"description": "Older", "start": "2026-08-30T20:00:00-03:00", "duration": 600}]`
+	options := importers.TrackerOptions{From: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)}
+	tracked, skipped, err := importers.ParseTrackerJSON(strings.NewReader(toggl), options)
+	if err != nil {
+		t.Fatalf("Error parsing Toggl JSON: %v", err)
+	}
+	if len(tracked) != 1 || tracked[0].Minutes() != 45 {
+		t.Errorf("Unexpected Toggl entries %+v", tracked)
+	}
+	if len(skipped) != 1 || skipped[0].Entry.Description != "Older" {
+		t.Errorf("Expected the entry before the range skipped, got %+v", skipped)
+	}
+
+	if _, _, err := importers.ParseTrackerJSON(strings.NewReader(`{"entries": 1}`), importers.TrackerOptions{}); err == nil {
+		t.Error("Expected an error for a document without time entries")
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:incident-1@example.com",
+		"DTSTART;TZID=America/Sao_Paulo:20260901T220000",
+		"DTEND;TZID=America/Sao_Paulo:20260902T003000",
+		"SUMMARY:Database failover",
+		"DESCRIPTION:Paged by the monitoring.\\nTicket: https://acme.atlassian.net/",
+		" browse/OPS-7",
+		"ORGANIZER;CN=Ana:mailto:ana@example.com",
+		"CATEGORIES:on-call,database",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DESCRIPTION:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:incident-2@example.com",
+		"DTSTART:20260905T100000Z",
+		"DURATION:PT45M",
+		"SUMMARY:Restart workers",
+		"URL:https://github.com/acme/api/issues/9",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled@example.com",
+		"DTSTART:20260906T100000Z",
+		"DTEND:20260906T110000Z",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday@example.com",
+		"DTSTART;VALUE=DATE:20260907",
+		"DTEND;VALUE=DATE:20260908",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:october@example.com",
+		"DTSTART:20261001T100000Z",
+		"DTEND:20261001T110000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	options := importers.TrackerOptions{
+		From: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tracked, skipped, err := importers.ParseTrackerExport(strings.NewReader(calendar), "oncall.ics", options)
+	if err != nil {
+		t.Fatalf("Error parsing iCalendar file: %v", err)
+	}
+	if len(tracked) != 2 {
+		t.Fatalf("Expected the 2 timed events of September, got %+v", tracked)
+	}
+	reasons := []string{"cancelled event", "all-day event", "outside the imported range"}
+	if len(skipped) != len(reasons) {
+		t.Fatalf("Expected the cancelled, all-day and October events skipped, got %+v", skipped)
+	}
+	for i, reason := range reasons {
+		if skipped[i].Reason != reason || skipped[i].Entry.Line != i+3 {
+			t.Errorf("Expected event %d skipped as %q, got %+v", i+3, reason, skipped[i])
+		}
+	}
+
+	failover := tracked[0]
+	if failover.ID != "incident-1@example.com" || failover.User != "ana@example.com" || failover.Description != "Database failover" {
+		t.Errorf("Unexpected event %+v", failover)
+	}
+	if failover.Start.UTC() != time.Date(2026, time.September, 2, 1, 0, 0, 0, time.UTC) || failover.Minutes() != 150 {
+		t.Errorf("Expected 150 minutes from 22:00 in Sao Paulo, got %v and %d minutes", failover.Start, failover.Minutes())
+	}
+	if !strings.Contains(failover.Notes, "https://acme.atlassian.net/browse/OPS-7") {
+		t.Errorf("Expected the folded description to be joined, got %q", failover.Notes)
+	}
+	if len(failover.Tags) != 2 || failover.Tags[0] != "on-call" {
+		t.Errorf("Expected the categories as tags, got %v", failover.Tags)
+	}
+
+	if tracked[1].Minutes() != 45 || tracked[1].URL != "https://github.com/acme/api/issues/9" || tracked[1].Line != 2 {
+		t.Errorf("Expected 45 minutes from the duration with the event URL, got %+v", tracked[1])
+	}
+}
+
+func TestParseICSRecurringEvents(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:rota@example.com",
+		"DTSTART;TZID=America/Sao_Paulo:20260801T200000",
+		"DTEND;TZID=America/Sao_Paulo:20260801T220000",
+		"RRULE:FREQ=WEEKLY;BYDAY=SA,SU;UNTIL=20261231T000000Z",
+		"EXDATE;TZID=America/Sao_Paulo:20260906T200000",
+		"SUMMARY:On-call",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:rota@example.com",
+		"RECURRENCE-ID;TZID=America/Sao_Paulo:20260912T200000",
+		"DTSTART;TZID=America/Sao_Paulo:20260912T210000",
+		"DTEND;TZID=America/Sao_Paulo:20260912T230000",
+		"SUMMARY:On-call, moved",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:migration@example.com",
+		"DTSTART:20260830T220000Z",
+		"DURATION:PT1H",
+		"RRULE:FREQ=DAILY;COUNT=2",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:review@example.com",
+		"DTSTART:20260901T220000Z",
+		"DURATION:PT1H",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=1,15",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	options := importers.TrackerOptions{
+		From: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tracked, skipped, err := importers.ParseICS(strings.NewReader(calendar), options)
+	if err != nil {
+		t.Fatalf("Error parsing iCalendar file: %v", err)
+	}
+
+	// The weekends of September, without the excluded Sunday and with the moved Saturday
+	if len(tracked) != 7 {
+		t.Fatalf("Expected 7 occurrences in September, got %d: %+v", len(tracked), tracked)
+	}
+	if tracked[0].ID != "rota@example.com/20260905T230000Z" || !tracked[0].Start.Equal(time.Date(2026, time.September, 5, 23, 0, 0, 0, time.UTC)) || tracked[0].Minutes() != 120 {
+		t.Errorf("Expected the first Saturday keyed by its start, got %+v", tracked[0])
+	}
+	ids := make(map[string]bool)
+	for _, entry := range tracked {
+		ids[entry.ID] = true
+	}
+	if ids["rota@example.com/20260906T230000Z"] || len(ids) != 7 {
+		t.Errorf("Expected the excluded Sunday left out and unique IDs, got %v", ids)
+	}
+	moved := tracked[len(tracked)-1]
+	if moved.ID != "rota@example.com/20260912T230000Z" || moved.Description != "On-call, moved" || !moved.Start.Equal(time.Date(2026, time.September, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the moved occurrence keyed by its original start, got %+v", moved)
+	}
+
+	if len(skipped) != 2 {
+		t.Fatalf("Expected the finished and unsupported series skipped, got %+v", skipped)
+	}
+	if !strings.Contains(skipped[0].Reason, "without occurrences") || !strings.Contains(skipped[1].Reason, "BYMONTHDAY") {
+		t.Errorf("Unexpected skip reasons %q and %q", skipped[0].Reason, skipped[1].Reason)
+	}
+}
+
+func TestReadGitCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	repository := filepath.Join(root, "team", "api")
+	if err := os.MkdirAll(repository, 0o755); err != nil {
+		t.Fatalf("Error creating repository directory: %v", err)
+	}
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repository}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	commit := func(author, authored, committed, message string) {
+		env := []string{"GIT_AUTHOR_DATE=" + authored, "GIT_COMMITTER_DATE=" + committed,
+			"GIT_AUTHOR_NAME=" + author, "GIT_AUTHOR_EMAIL=" + author + "@example.com",
+			"GIT_COMMITTER_NAME=" + author, "GIT_COMMITTER_EMAIL=" + author + "@example.com"}
+		git(env, "commit", "--allow-empty", "-q", "-m", message)
+	}
+	git(nil, "init", "-q")
+	commit("ana+oncall", "2026-09-01T21:00:00Z", "2026-09-01T21:00:00Z", "Fix failover OPS-7\n\nDetails in the body")
+	commit("bruno", "2026-09-01T22:00:00Z", "2026-09-01T22:00:00Z", "Not ana")
+	commit("anaaoncall", "2026-09-02T22:00:00Z", "2026-09-02T22:00:00Z", "Matched only as a regular expression")
+	commit("ana+oncall", "2026-08-31T22:00:00Z", "2026-09-02T23:00:00Z", "Cherry-picked from August")
+	commit("ana+oncall", "2026-09-30T23:00:00Z", "2026-10-02T09:00:00Z", "Rebased in October")
+	commit("ana+oncall", "2026-10-01T21:00:00Z", "2026-10-01T21:00:00Z", "Next month")
+
+	repositories, err := importers.FindGitRepositories([]string{root})
+	if err != nil {
+		t.Fatalf("Error finding repositories: %v", err)
+	}
+	if len(repositories) != 1 || repositories[0] != repository {
+		t.Fatalf("Expected the nested repository, got %v", repositories)
+	}
+
+	since := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	commits, err := importers.ReadGitCommits(context.Background(), repositories, "ana+oncall@example.com", since, since.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Error reading commits: %v", err)
+	}
+
+	// Commits are selected by author time, whenever they were committed
+	if len(commits) != 2 {
+		t.Fatalf("Expected the two commits authored by ana in September, got %+v", commits)
+	}
+	messages := map[string]entities.Commit{}
+	for _, commit := range commits {
+		messages[commit.Message] = commit
+	}
+	first, ok := messages["Fix failover OPS-7\n\nDetails in the body"]
+	if !ok || first.Repository != "api" || !first.Time.Equal(time.Date(2026, time.September, 1, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected commits %+v", commits)
+	}
+	if _, ok := messages["Rebased in October"]; !ok {
+		t.Errorf("Expected the rebased commit authored in September, got %+v", commits)
+	}
+}
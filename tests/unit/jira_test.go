@@ -0,0 +1,129 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MateSousa/overtime-script/pkg/adapters/repositories"
+	domainrepos "github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// newJiraFake is a local HTTP stand-in for the Jira REST API serving a single issue
+func newJiraFake(t *testing.T, calls *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if r.URL.Path != "/rest/api/2/issue/OPS-12" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+			return
+		}
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot@example.com" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"key": "OPS-12",
+			"fields": {
+				"summary": "Database failover",
+				"project": {"key": "OPS", "name": "Operations", "projectCategory": {"name": "Internal"}},
+				"customfield_10050": {"value": "Acme"}
+			}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestJiraTicketRepositoryGetTicket(t *testing.T) {
+	calls := 0
+	server := newJiraFake(t, &calls)
+
+	jira := repositories.NewJiraTicketRepository(repositories.JiraOptions{
+		BaseURL:     server.URL + "/",
+		Email:       "bot@example.com",
+		Token:       "secret",
+		ClientField: "customfield_10050",
+	})
+
+	info, err := jira.GetTicket(context.Background(), "OPS-12")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.Key != "OPS-12" || info.Title != "Database failover" || info.Project != "Operations" || info.Client != "Acme" {
+		t.Errorf("Unexpected ticket %+v", info)
+	}
+
+	// Without a client field the project category names the client
+	jira = repositories.NewJiraTicketRepository(repositories.JiraOptions{BaseURL: server.URL, Email: "bot@example.com", Token: "secret"})
+	info, err = jira.GetTicket(context.Background(), "OPS-12")
+	if err != nil || info.Client != "Internal" {
+		t.Errorf("Expected the project category as client, got %+v (%v)", info, err)
+	}
+
+	if _, err := jira.GetTicket(context.Background(), "OPS-99"); err == nil {
+		t.Error("Expected an error for a missing issue")
+	}
+}
+
+func TestCachedTicketRepository(t *testing.T) {
+	calls := 0
+	server := newJiraFake(t, &calls)
+
+	tickets := repositories.NewCachedTicketRepository(repositories.NewJiraTicketRepository(repositories.JiraOptions{
+		BaseURL: server.URL,
+		Email:   "bot@example.com",
+		Token:   "secret",
+	}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := tickets.GetTicket(context.Background(), "OPS-12"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := tickets.GetTicket(context.Background(), "OPS-99"); err == nil {
+			t.Fatal("Expected the cached error for a missing issue")
+		}
+	}
+
+	// Missing tickets are cached too
+	if calls != 2 {
+		t.Errorf("Expected one request per ticket, got %d", calls)
+	}
+}
+
+func TestCachedTicketRepositoryRetriesTransientFailures(t *testing.T) {
+	calls := 0
+	fake := newJiraFake(t, &calls)
+	unavailable := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable {
+			unavailable = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	tickets := repositories.NewCachedTicketRepository(repositories.NewJiraTicketRepository(repositories.JiraOptions{
+		BaseURL: server.URL,
+		Email:   "bot@example.com",
+		Token:   "secret",
+	}))
+
+	if _, err := tickets.GetTicket(context.Background(), "OPS-12"); err == nil {
+		t.Fatal("Expected the server error")
+	}
+	info, err := tickets.GetTicket(context.Background(), "OPS-12")
+	if err != nil || info.Title != "Database failover" {
+		t.Fatalf("Expected the ticket once Jira recovered, got %+v (%v)", info, err)
+	}
+
+	_, err = tickets.GetTicket(context.Background(), "OPS-99")
+	if !errors.Is(err, domainrepos.ErrTicketNotFound) {
+		t.Errorf("Expected ErrTicketNotFound for a missing issue, got %v", err)
+	}
+}
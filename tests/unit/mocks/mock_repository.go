@@ -12,11 +12,19 @@ import (
 type MockOvertimeRepository struct {
 	entries            map[string][]entities.OvertimeEntry
 	reports            map[string]*entities.OvertimeReport
+	deliveries         map[string]*entities.DeliveryRecord
 	ErrorToReturn      error
 	GetPeriodError     error
 	SaveReportError    error
 	GetMergedError     error
 	MergeEntriesError  error
+	GetDeliveryError   error
+	SaveDeliveryError  error
+	QuarantineError    error
+	SaveEntriesError   error
+	Saved              [][]entities.OvertimeEntry
+	Quarantined        []entities.QuarantinedEntry
+	malformed          map[string][]entities.QuarantinedEntry
 }
 
 // NewMockOvertimeRepository creates a new mock repository
@@ -24,13 +32,15 @@ func NewMockOvertimeRepository() *MockOvertimeRepository {
 	return &MockOvertimeRepository{
 		entries: make(map[string][]entities.OvertimeEntry),
 		reports: make(map[string]*entities.OvertimeReport),
+		deliveries: make(map[string]*entities.DeliveryRecord),
+		malformed: make(map[string][]entities.QuarantinedEntry),
 	}
 }
 
 // GetOvertimeEntriesForPeriod fetches overtime entries for a specific time period
-func (m *MockOvertimeRepository) GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, error) {
+func (m *MockOvertimeRepository) GetOvertimeEntriesForPeriod(ctx context.Context, start, end time.Time) ([]entities.OvertimeEntry, []entities.QuarantinedEntry, error) {
 	if m.GetPeriodError != nil {
-		return nil, m.GetPeriodError
+		return nil, nil, m.GetPeriodError
 	}
 
 	// Generate key from start and end dates
@@ -39,10 +49,41 @@ func (m *MockOvertimeRepository) GetOvertimeEntriesForPeriod(ctx context.Context
 	// Return entries for this period, or empty slice if none
 	entries, ok := m.entries[key]
 	if !ok {
-		return []entities.OvertimeEntry{}, nil
+		return []entities.OvertimeEntry{}, m.malformed[key], nil
 	}
 	
-	return entries, nil
+	return entries, m.malformed[key], nil
+}
+
+// SaveOvertimeEntries records the saved entries, naming each save after its position
+func (m *MockOvertimeRepository) SaveOvertimeEntries(ctx context.Context, entries []entities.OvertimeEntry) (string, error) {
+	if m.SaveEntriesError != nil {
+		return "", m.SaveEntriesError
+	}
+	m.Saved = append(m.Saved, entries)
+	return fmt.Sprintf("overtime-import-%d", len(m.Saved)), nil
+}
+
+// GetImportedIDs returns the external IDs of the saved entries
+func (m *MockOvertimeRepository) GetImportedIDs(ctx context.Context) (map[string]string, error) {
+	ids := make(map[string]string)
+	for i, entries := range m.Saved {
+		for _, entry := range entries {
+			if entry.ExternalID != "" {
+				ids[entry.ExternalID] = fmt.Sprintf("overtime-import-%d", i+1)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// QuarantineEntries records the quarantined entries
+func (m *MockOvertimeRepository) QuarantineEntries(ctx context.Context, quarantined []entities.QuarantinedEntry) error {
+	if m.QuarantineError != nil {
+		return m.QuarantineError
+	}
+	m.Quarantined = append(m.Quarantined, quarantined...)
+	return nil
 }
 
 // SaveOvertimeReport persists an overtime report
@@ -85,7 +126,7 @@ func (m *MockOvertimeRepository) MergeOvertimeEntries(ctx context.Context, entri
 	
 	// Add entries to the report
 	for _, entry := range entries {
-		existingReport.AddEntry(entry.TicketURL, entry.Minutes)
+		existingReport.AppendEntry(entry)
 	}
 	
 	// Save the updated report
@@ -94,13 +135,116 @@ func (m *MockOvertimeRepository) MergeOvertimeEntries(ctx context.Context, entri
 	return existingReport, nil
 }
 
+// GetDeliveryRecord retrieves the delivery record of a period, or nil if its report was never delivered
+func (m *MockOvertimeRepository) GetDeliveryRecord(ctx context.Context, period string) (*entities.DeliveryRecord, error) {
+	if m.GetDeliveryError != nil {
+		return nil, m.GetDeliveryError
+	}
+	return m.deliveries[period], nil
+}
+
+// SaveDeliveryRecord persists the delivery record of a period
+func (m *MockOvertimeRepository) SaveDeliveryRecord(ctx context.Context, record *entities.DeliveryRecord) error {
+	if m.SaveDeliveryError != nil {
+		return m.SaveDeliveryError
+	}
+	m.deliveries[record.Period] = record
+	return nil
+}
+
 // AddTestEntry adds a test entry to the repository
 func (m *MockOvertimeRepository) AddTestEntry(entry entities.OvertimeEntry, startDate, endDate time.Time) {
 	key := fmt.Sprintf("%s-%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
 	m.entries[key] = append(m.entries[key], entry)
 }
 
+// AddTestMalformedEntry adds an entry that could not be read to the repository
+func (m *MockOvertimeRepository) AddTestMalformedEntry(entry entities.QuarantinedEntry, startDate, endDate time.Time) {
+	key := fmt.Sprintf("%s-%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	m.malformed[key] = append(m.malformed[key], entry)
+}
+
 // AddTestReport adds a test report to the repository
 func (m *MockOvertimeRepository) AddTestReport(report *entities.OvertimeReport) {
 	m.reports[report.Period] = report
-}
\ No newline at end of file
+}
+
+// MockOnCallRepository is a mock implementation of the OnCallRepository interface
+type MockOnCallRepository struct {
+	owners        map[string][]string
+	ErrorToReturn error
+}
+
+// NewMockOnCallRepository creates a new mock on-call schedule
+func NewMockOnCallRepository() *MockOnCallRepository {
+	return &MockOnCallRepository{
+		owners: make(map[string][]string),
+	}
+}
+
+// GetOnCallOwners returns the owners that were on-call on the given day
+func (m *MockOnCallRepository) GetOnCallOwners(ctx context.Context, day time.Time) ([]string, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	return m.owners[day.Format("2006-01-02")], nil
+}
+
+// AddOnCall schedules owners as on-call on the given day
+func (m *MockOnCallRepository) AddOnCall(day time.Time, owners ...string) {
+	key := day.Format("2006-01-02")
+	m.owners[key] = append(m.owners[key], owners...)
+}
+
+// MockApprovalRepository is a mock implementation of the ApprovalRepository interface
+type MockApprovalRepository struct {
+	Approvals     map[string]entities.Approval
+	ErrorToReturn error
+}
+
+// NewMockApprovalRepository creates a new mock approval repository
+func NewMockApprovalRepository() *MockApprovalRepository {
+	return &MockApprovalRepository{
+		Approvals: make(map[string]entities.Approval),
+	}
+}
+
+// GetApprovals returns the approval of every entry source
+func (m *MockApprovalRepository) GetApprovals(ctx context.Context) (map[string]entities.Approval, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	return m.Approvals, nil
+}
+
+// SetApproval records a decision on the entries of a source
+func (m *MockApprovalRepository) SetApproval(ctx context.Context, source string, approval entities.Approval) error {
+	if m.ErrorToReturn != nil {
+		return m.ErrorToReturn
+	}
+	m.Approvals[source] = approval
+	return nil
+}
+
+// MockTicketRepository is a mock implementation of the TicketRepository interface
+type MockTicketRepository struct {
+	Tickets  map[string]entities.TicketInfo
+	Requests []string
+}
+
+// NewMockTicketRepository creates a new mock ticket repository
+func NewMockTicketRepository() *MockTicketRepository {
+	return &MockTicketRepository{
+		Tickets: make(map[string]entities.TicketInfo),
+	}
+}
+
+// GetTicket returns the ticket with the given key, or an error for unknown tickets
+func (m *MockTicketRepository) GetTicket(ctx context.Context, key string) (*entities.TicketInfo, error) {
+	m.Requests = append(m.Requests, key)
+	info, ok := m.Tickets[key]
+	if !ok {
+		return nil, fmt.Errorf("ticket not found: %s", key)
+	}
+	return &info, nil
+}
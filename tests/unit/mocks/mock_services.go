@@ -2,44 +2,55 @@ package mocks
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/MateSousa/overtime-script/pkg/domain/entities"
 )
 
 // MockReportExporter is a mock implementation of the ReportExporter interface
 type MockReportExporter struct {
-	ExcelFilePath      string
-	CSVFilePath        string
-	ExportToExcelError error
-	ExportToCSVError   error
-	ExportToExcelCalls int
-	ExportToCSVCalls   int
+	ExportError error
+	ExportCalls map[string]int
+	formats     []entities.ExportFormat
 }
 
-// NewMockReportExporter creates a new mock report exporter
+// NewMockReportExporter creates a new mock report exporter supporting xlsx and csv
 func NewMockReportExporter() *MockReportExporter {
 	return &MockReportExporter{
-		ExcelFilePath: "test-report.xlsx",
-		CSVFilePath:   "test-report.csv",
+		ExportCalls: make(map[string]int),
+		formats: []entities.ExportFormat{
+			{Name: "xlsx", Extension: ".xlsx", MIMEType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+			{Name: "csv", Extension: ".csv", MIMEType: "text/csv"},
+		},
 	}
 }
 
-// ExportToExcel exports the report to an Excel file
-func (m *MockReportExporter) ExportToExcel(ctx context.Context, report *entities.OvertimeReport) (string, error) {
-	m.ExportToExcelCalls++
-	if m.ExportToExcelError != nil {
-		return "", m.ExportToExcelError
+// Export writes a placeholder document naming the format
+func (m *MockReportExporter) Export(ctx context.Context, report *entities.OvertimeReport, format string, w io.Writer) error {
+	m.ExportCalls[format]++
+	if m.ExportError != nil {
+		return m.ExportError
 	}
-	return m.ExcelFilePath, nil
+	_, err := fmt.Fprintf(w, "%s report for %s", format, report.Period)
+	return err
 }
 
-// ExportToCSV exports the report to a CSV file
-func (m *MockReportExporter) ExportToCSV(ctx context.Context, report *entities.OvertimeReport) (string, error) {
-	m.ExportToCSVCalls++
-	if m.ExportToCSVError != nil {
-		return "", m.ExportToCSVError
+// Format returns the metadata of the named format
+func (m *MockReportExporter) Format(name string) (entities.ExportFormat, error) {
+	for _, format := range m.formats {
+		if format.Name == name {
+			return format, nil
+		}
 	}
-	return m.CSVFilePath, nil
+	return entities.ExportFormat{}, fmt.Errorf("unsupported report format %q", name)
+}
+
+// Formats lists the supported formats
+func (m *MockReportExporter) Formats() []entities.ExportFormat {
+	return m.formats
 }
 
 // MockNotificationService is a mock implementation of the NotificationService interface
@@ -47,7 +58,17 @@ type MockNotificationService struct {
 	SendEmailError   error
 	SendEmailCalls   int
 	LastReportSent   *entities.OvertimeReport
-	LastAttachmentPath string
+	LastAttachmentPaths []string
+	LastAttachmentTypes map[string]string
+	LastAttachments     map[string]string
+	Receipt            *entities.DeliveryReceipt
+	SendDailySummaryError error
+	DailySummaryCalls     int
+	LastDailySummary      *entities.DailySummary
+	SendReminderError     error
+	RemindersSent         []entities.Reminder
+	PolicyAlertsSent      []entities.PolicyAlert
+	SendPolicyAlertError  error
 }
 
 // NewMockNotificationService creates a new mock notification service
@@ -55,10 +76,48 @@ func NewMockNotificationService() *MockNotificationService {
 	return &MockNotificationService{}
 }
 
-// SendReportByEmail sends an overtime report via email with an attachment
-func (m *MockNotificationService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachmentPath string) error {
+// SendReportByEmail sends an overtime report via email with the given attachments
+func (m *MockNotificationService) SendReportByEmail(ctx context.Context, report *entities.OvertimeReport, attachments []entities.Attachment) (*entities.DeliveryReceipt, error) {
 	m.SendEmailCalls++
 	m.LastReportSent = report
-	m.LastAttachmentPath = attachmentPath
-	return m.SendEmailError
-}
\ No newline at end of file
+	m.LastAttachmentPaths = nil
+	m.LastAttachmentTypes = make(map[string]string)
+	// Keep the attachment contents, since exported files are removed after sending
+	m.LastAttachments = make(map[string]string)
+	for _, attachment := range attachments {
+		m.LastAttachmentPaths = append(m.LastAttachmentPaths, attachment.Path)
+		m.LastAttachmentTypes[filepath.Base(attachment.Path)] = attachment.MIMEType
+		if content, err := os.ReadFile(attachment.Path); err == nil {
+			m.LastAttachments[filepath.Base(attachment.Path)] = string(content)
+		}
+	}
+	if m.SendEmailError != nil {
+		return nil, m.SendEmailError
+	}
+	return m.Receipt, nil
+}
+
+// SendDailySummary sends the summary of a processed day
+func (m *MockNotificationService) SendDailySummary(ctx context.Context, summary *entities.DailySummary) error {
+	m.DailySummaryCalls++
+	m.LastDailySummary = summary
+	return m.SendDailySummaryError
+}
+
+// SendReminder reminds an owner about a day without overtime entries
+func (m *MockNotificationService) SendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	if m.SendReminderError != nil {
+		return m.SendReminderError
+	}
+	m.RemindersSent = append(m.RemindersSent, *reminder)
+	return nil
+}
+
+// SendPolicyAlert records the overtime limit violations sent to the manager
+func (m *MockNotificationService) SendPolicyAlert(ctx context.Context, alert *entities.PolicyAlert) error {
+	if m.SendPolicyAlertError != nil {
+		return m.SendPolicyAlertError
+	}
+	m.PolicyAlertsSent = append(m.PolicyAlertsSent, *alert)
+	return nil
+}
@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/adapters/notification"
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/usecases"
+	"github.com/MateSousa/overtime-script/tests/unit/mocks"
+)
+
+func TestMultiNotificationServiceDeliversToAllChannels(t *testing.T) {
+	email := mocks.NewMockNotificationService()
+	slack := mocks.NewMockNotificationService()
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "email", Service: email, Required: true},
+		notification.Channel{Name: "slack", Service: slack},
+	)
+
+	report := newTestReport()
+	if _, err := service.SendReportByEmail(context.Background(), report, []entities.Attachment{{Path: "report.xlsx"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if email.SendEmailCalls != 1 || slack.SendEmailCalls != 1 {
+		t.Errorf("Expected one delivery per channel, got email=%d slack=%d", email.SendEmailCalls, slack.SendEmailCalls)
+	}
+
+	results := service.Results()
+	if len(results) != 2 || !results[0].Delivered() || !results[1].Delivered() {
+		t.Errorf("Expected two delivered results, got %+v", results)
+	}
+}
+
+func TestMultiNotificationServiceOptionalFailure(t *testing.T) {
+	email := mocks.NewMockNotificationService()
+	slack := mocks.NewMockNotificationService()
+	slack.SendEmailError = errors.New("webhook down")
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "email", Service: email, Required: true},
+		notification.Channel{Name: "slack", Service: slack, Required: false},
+	)
+
+	if _, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "report.xlsx"}}); err != nil {
+		t.Fatalf("Expected optional failure to be tolerated, got %v", err)
+	}
+
+	results := service.Results()
+	if results[1].Delivered() || !errors.Is(results[1].Err, slack.SendEmailError) {
+		t.Errorf("Expected slack failure to be recorded, got %+v", results[1])
+	}
+}
+
+func TestMultiNotificationServiceRequiredFailure(t *testing.T) {
+	email := mocks.NewMockNotificationService()
+	email.SendEmailError = errors.New("ses throttled")
+	archive := mocks.NewMockNotificationService()
+	archive.SendEmailError = errors.New("disk full")
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "email", Service: email, Required: true},
+		notification.Channel{Name: "archive", Service: archive, Required: true},
+	)
+
+	_, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "report.xlsx"}})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	// Every required failure is aggregated
+	if !errors.Is(err, email.SendEmailError) || !errors.Is(err, archive.SendEmailError) {
+		t.Errorf("Expected both channel errors to be aggregated, got %v", err)
+	}
+}
+
+func TestMultiNotificationServiceAllOptionalFailed(t *testing.T) {
+	slack := mocks.NewMockNotificationService()
+	slack.SendEmailError = errors.New("webhook down")
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "slack", Service: slack},
+	)
+
+	if _, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "report.xlsx"}}); err == nil {
+		t.Error("Expected error when no channel delivered the report, got nil")
+	}
+}
+
+func TestMultiNotificationServiceDailySummary(t *testing.T) {
+	slack := mocks.NewMockNotificationService()
+	archive := notification.NewArchiveService(t.TempDir())
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "slack", Service: slack, Required: true},
+		notification.Channel{Name: "archive", Service: archive, Required: true},
+	)
+
+	summary := &entities.DailySummary{Date: time.Now()}
+	if err := service.SendDailySummary(context.Background(), summary); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if slack.DailySummaryCalls != 1 {
+		t.Errorf("Expected slack to receive the daily summary, got %d calls", slack.DailySummaryCalls)
+	}
+
+	// Channels without daily summary support are skipped
+	if len(service.Results()) != 1 {
+		t.Errorf("Expected one result, got %d", len(service.Results()))
+	}
+}
+
+func TestMultiNotificationServiceMergesReceipts(t *testing.T) {
+	email := mocks.NewMockNotificationService()
+	email.Receipt = &entities.DeliveryReceipt{
+		MessageIDs: map[string]string{"ses": "0100-message-id"},
+		Recipients: []string{"finance@example.com"},
+	}
+	slack := mocks.NewMockNotificationService()
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "email", Service: email, Required: true},
+		notification.Channel{Name: "slack", Service: slack},
+	)
+
+	receipt, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "report.xlsx"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if receipt.MessageIDs["email"] != "0100-message-id" {
+		t.Errorf("Expected message ID keyed by channel name, got %v", receipt.MessageIDs)
+	}
+
+	if len(receipt.Recipients) != 1 {
+		t.Errorf("Expected recipients of all channels, got %v", receipt.Recipients)
+	}
+}
+
+func TestMultiNotificationServiceKeepsReportResultsAfterPolicyAlert(t *testing.T) {
+	// The required email delivers the report but fails the alert, the optional Slack fails the report
+	email := mocks.NewMockNotificationService()
+	email.SendPolicyAlertError = errors.New("ses throttled")
+	slack := mocks.NewMockNotificationService()
+	slack.SendEmailError = errors.New("webhook down")
+
+	service := notification.NewMultiNotificationService(
+		notification.Channel{Name: "email", Service: email, Required: true},
+		notification.Channel{Name: "slack", Service: slack},
+	)
+	repo := mocks.NewMockOvertimeRepository()
+	uc := usecases.NewOvertimeUseCase(repo, mocks.NewMockReportExporter(), service,
+		usecases.WithOvertimeLimits(entities.OvertimeLimits{DailyMinutes: 120}),
+		usecases.WithPolicyAlerts("manager@example.com"))
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	report := entities.NewOvertimeReport(entities.PeriodFor(prevMonth))
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 180, Owner: "ana", Date: prevMonth})
+	repo.AddTestReport(report)
+
+	if err := uc.GenerateMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected the report delivered, got %v", err)
+	}
+
+	results := service.ReportResults()
+	if len(results) != 2 || !results[0].Delivered() || results[1].Delivered() {
+		t.Errorf("Expected the report delivered by email only, got %+v", results)
+	}
+	if alert := service.Results(); len(alert) != 2 || alert[0].Delivered() {
+		t.Errorf("Expected the failed alert as the last delivery, got %+v", alert)
+	}
+}
@@ -3,6 +3,9 @@ package unit
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -124,8 +127,8 @@ func TestGenerateMonthlyReport(t *testing.T) {
 	}
 	
 	// Check exporter was called
-	if exporter.ExportToExcelCalls != 1 {
-		t.Errorf("Expected ExportToExcel to be called once, got %d", exporter.ExportToExcelCalls)
+	if exporter.ExportCalls["xlsx"] != 1 {
+		t.Errorf("Expected xlsx export to be called once, got %d", exporter.ExportCalls["xlsx"])
 	}
 	
 	// Check notification service was called
@@ -138,8 +141,8 @@ func TestGenerateMonthlyReport(t *testing.T) {
 		t.Error("Expected correct report to be sent to notification service")
 	}
 	
-	if notifier.LastAttachmentPath != exporter.ExcelFilePath {
-		t.Errorf("Expected attachment path %s, got %s", exporter.ExcelFilePath, notifier.LastAttachmentPath)
+	if len(notifier.LastAttachmentPaths) != 1 || filepath.Ext(notifier.LastAttachmentPaths[0]) != ".xlsx" {
+		t.Errorf("Expected a single xlsx attachment, got %v", notifier.LastAttachmentPaths)
 	}
 }
 
@@ -173,8 +176,8 @@ func TestTestMonthlyReport(t *testing.T) {
 	}
 	
 	// Check exporter was called
-	if exporter.ExportToExcelCalls != 1 {
-		t.Errorf("Expected ExportToExcel to be called once, got %d", exporter.ExportToExcelCalls)
+	if exporter.ExportCalls["xlsx"] != 1 {
+		t.Errorf("Expected xlsx export to be called once, got %d", exporter.ExportCalls["xlsx"])
 	}
 	
 	// Check notification service was called
@@ -187,7 +190,614 @@ func TestTestMonthlyReport(t *testing.T) {
 		t.Error("Expected correct report to be sent to notification service")
 	}
 	
-	if notifier.LastAttachmentPath != exporter.ExcelFilePath {
-		t.Errorf("Expected attachment path %s, got %s", exporter.ExcelFilePath, notifier.LastAttachmentPath)
+	if len(notifier.LastAttachmentPaths) != 1 || filepath.Ext(notifier.LastAttachmentPaths[0]) != ".xlsx" {
+		t.Errorf("Expected a single xlsx attachment, got %v", notifier.LastAttachmentPaths)
 	}
-}
\ No newline at end of file
+}
+
+func TestProcessYesterdayOvertimeDailySummary(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithDailySummary(true))
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	startOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location())
+	endOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, yesterday.Location())
+
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 40, Date: yesterday}, startOfYesterday, endOfYesterday)
+
+	// A failing notification must not fail the run
+	notifier.SendDailySummaryError = errors.New("webhook down")
+
+	if err := uc.ProcessYesterdayOvertime(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if notifier.DailySummaryCalls != 1 {
+		t.Fatalf("Expected SendDailySummary to be called once, got %d", notifier.DailySummaryCalls)
+	}
+
+	summary := notifier.LastDailySummary
+	if !summary.Date.Equal(startOfYesterday) {
+		t.Errorf("Expected summary date %v, got %v", startOfYesterday, summary.Date)
+	}
+
+	if summary.TotalMinutes() != 40 || summary.MonthReport.TotalTime != 40 {
+		t.Errorf("Expected 40 minutes for the day and month, got %d and %d", summary.TotalMinutes(), summary.MonthReport.TotalTime)
+	}
+}
+
+func TestProcessYesterdayOvertimeDailySummaryDisabled(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier)
+
+	if err := uc.ProcessYesterdayOvertime(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if notifier.DailySummaryCalls != 0 {
+		t.Errorf("Expected no daily summary by default, got %d calls", notifier.DailySummaryCalls)
+	}
+}
+
+func TestRemindMissingEntries(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+	onCall := mocks.NewMockOnCallRepository()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithOnCallReminders(onCall))
+
+	day := time.Date(2026, time.September, 14, 0, 0, 0, 0, time.UTC)
+	onCall.AddOnCall(day, "alice@example.com", "bob@example.com")
+
+	entries := []entities.OvertimeEntry{
+		{TicketURL: "http://jira.com/ticket1", Minutes: 30, Date: day, Owner: "Alice@example.com"},
+	}
+
+	reminders, err := uc.RemindMissingEntries(context.Background(), day, entries)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(reminders) != 1 || reminders[0].Owner != "bob@example.com" {
+		t.Fatalf("Expected a single reminder for bob@example.com, got %+v", reminders)
+	}
+
+	if len(notifier.RemindersSent) != 1 || !notifier.RemindersSent[0].Date.Equal(day) {
+		t.Errorf("Expected reminder for %v to be sent, got %+v", day, notifier.RemindersSent)
+	}
+}
+
+func TestProcessYesterdayOvertimeSendsReminders(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+	onCall := mocks.NewMockOnCallRepository()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithOnCallReminders(onCall))
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	onCall.AddOnCall(yesterday, "carol@example.com")
+
+	if err := uc.ProcessYesterdayOvertime(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notifier.RemindersSent) != 1 || notifier.RemindersSent[0].Owner != "carol@example.com" {
+		t.Errorf("Expected reminder for carol@example.com, got %+v", notifier.RemindersSent)
+	}
+}
+
+func TestGenerateMonthlyReportRecordsDelivery(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+	notifier.Receipt = &entities.DeliveryReceipt{
+		MessageIDs: map[string]string{"email": "0100-message-id"},
+		Recipients: []string{"finance@example.com"},
+	}
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier)
+
+	monthPeriod := time.Now().AddDate(0, -1, 0).Format("Jan-2006")
+	repo.AddTestReport(entities.NewOvertimeReport(monthPeriod))
+
+	ctx := context.Background()
+	if err := uc.GenerateMonthlyReport(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	record, err := repo.GetDeliveryRecord(ctx, monthPeriod)
+	if err != nil || record == nil {
+		t.Fatalf("Expected delivery record for %s, got %v (error %v)", monthPeriod, record, err)
+	}
+
+	if record.MessageIDs["email"] != "0100-message-id" {
+		t.Errorf("Expected message ID to be recorded, got %v", record.MessageIDs)
+	}
+
+	if len(record.Recipients) != 1 || record.Recipients[0] != "finance@example.com" {
+		t.Errorf("Expected recipients to be recorded, got %v", record.Recipients)
+	}
+
+	// A second run must not send the report again
+	err = uc.GenerateMonthlyReport(ctx)
+	if !errors.Is(err, usecases.ErrReportAlreadyDelivered) {
+		t.Errorf("Expected ErrReportAlreadyDelivered, got %v", err)
+	}
+
+	if notifier.SendEmailCalls != 1 {
+		t.Errorf("Expected a single delivery, got %d", notifier.SendEmailCalls)
+	}
+}
+
+func TestResendMonthlyReport(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier)
+
+	ctx := context.Background()
+	repo.AddTestReport(entities.NewOvertimeReport("Sep-2026"))
+	repo.SaveDeliveryRecord(ctx, &entities.DeliveryRecord{Period: "Sep-2026", SentAt: time.Now()})
+
+	// Both period notations are accepted
+	for _, month := range []string{"Sep-2026", "2026-09"} {
+		if err := uc.ResendMonthlyReport(ctx, month); err != nil {
+			t.Fatalf("Expected no error resending %s, got %v", month, err)
+		}
+	}
+
+	if notifier.SendEmailCalls != 2 {
+		t.Errorf("Expected the report to be sent twice, got %d", notifier.SendEmailCalls)
+	}
+
+	if err := uc.ResendMonthlyReport(ctx, "September"); err == nil {
+		t.Error("Expected error for an invalid month, got nil")
+	}
+}
+
+func TestTestMonthlyReportDoesNotRecordDelivery(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier)
+
+	currentMonth := time.Now().Format("Jan-2006")
+	repo.AddTestReport(entities.NewOvertimeReport(currentMonth))
+
+	ctx := context.Background()
+	if err := uc.TestMonthlyReport(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if record, _ := repo.GetDeliveryRecord(ctx, currentMonth); record != nil {
+		t.Errorf("Expected no delivery record in test mode, got %+v", record)
+	}
+}
+
+func TestGenerateMonthlyReportAttachesConfiguredFormats(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithReportFormats("xlsx", "csv"))
+
+	monthPeriod := time.Now().AddDate(0, -1, 0).Format("Jan-2006")
+	repo.AddTestReport(entities.NewOvertimeReport(monthPeriod))
+
+	if err := uc.GenerateMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if exporter.ExportCalls["xlsx"] != 1 || exporter.ExportCalls["csv"] != 1 {
+		t.Errorf("Expected one export per format, got %v", exporter.ExportCalls)
+	}
+
+	attachments := notifier.LastAttachmentPaths
+	if len(attachments) != 2 || filepath.Ext(attachments[0]) != ".xlsx" || filepath.Ext(attachments[1]) != ".csv" {
+		t.Fatalf("Expected xlsx and csv attachments, got %v", attachments)
+	}
+
+	// Attachments carry the content type of their export format
+	if contentType := notifier.LastAttachmentTypes[filepath.Base(attachments[1])]; contentType != "text/csv" {
+		t.Errorf("Expected the csv attachment to be sent as text/csv, got %q", contentType)
+	}
+
+	content := notifier.LastAttachments[filepath.Base(attachments[1])]
+	if content != "csv report for "+monthPeriod {
+		t.Errorf("Expected exported content to be written to the attachment, got %q", content)
+	}
+}
+
+func TestGenerateMonthlyReportExportsToOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithOutputDir(outputDir))
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	repo.AddTestReport(entities.NewOvertimeReport(prevMonth.Format("Jan-2006")))
+
+	if err := uc.GenerateMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Files are named after the reported month, not the day of the run
+	attachment := notifier.LastAttachmentPaths[0]
+	expectedName := "overtime_" + prevMonth.Format("2006-01") + ".xlsx"
+	if filepath.Base(attachment) != expectedName {
+		t.Errorf("Expected attachment %s, got %s", expectedName, filepath.Base(attachment))
+	}
+
+	if !strings.HasPrefix(attachment, outputDir) {
+		t.Errorf("Expected attachment inside %s, got %s", outputDir, attachment)
+	}
+
+	if _, ok := notifier.LastAttachments[expectedName]; !ok {
+		t.Error("Expected the attachment to exist while sending")
+	}
+
+	// Exported files are removed once the report is sent
+	remaining, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("Error reading output directory: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Expected the output directory to be cleaned up, found %d entries", len(remaining))
+	}
+}
+
+func TestGenerateMonthlyReportUnsupportedFormat(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithReportFormats("doc"))
+
+	monthPeriod := time.Now().AddDate(0, -1, 0).Format("Jan-2006")
+	repo.AddTestReport(entities.NewOvertimeReport(monthPeriod))
+
+	if err := uc.GenerateMonthlyReport(context.Background()); err == nil {
+		t.Error("Expected error for an unsupported format, got nil")
+	}
+
+	if notifier.SendEmailCalls != 0 {
+		t.Errorf("Expected no email to be sent, got %d", notifier.SendEmailCalls)
+	}
+}
+
+func TestTestMonthlyReportAppliesRoundingPolicies(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	policies := entities.RoundingPolicies{Default: entities.RoundingPolicy{Increment: 15, Mode: entities.RoundUp}}
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithRoundingPolicies(policies))
+
+	report := entities.NewOvertimeReport(time.Now().Format("Jan-2006"))
+	report.AddEntry("http://jira.com/ticket1", 50)
+	report.AddEntry("http://jira.com/ticket2", 10)
+	repo.AddTestReport(report)
+
+	if err := uc.TestMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sent := notifier.LastReportSent
+	if sent.TotalTime != 60 || sent.BillableTime != 75 {
+		t.Errorf("Expected 60 raw and 75 billable minutes, got %d and %d", sent.TotalTime, sent.BillableTime)
+	}
+}
+
+func TestProcessYesterdayOvertimeAlertsCrossedLimits(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	limits := entities.OvertimeLimits{DailyMinutes: 120, MonthlyMinutes: 300}
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier,
+		usecases.WithOvertimeLimits(limits),
+		usecases.WithPolicyAlerts("manager@example.com"))
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	startOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location())
+	endOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, yesterday.Location())
+
+	// The owner already logged 200 minutes this month; bob stays within the limits
+	month := entities.NewOvertimeReport(entities.PeriodFor(now))
+	month.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket0", Minutes: 200, Owner: "ana", Date: yesterday.AddDate(0, 0, -20)})
+	repo.AddTestReport(month)
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 150, Owner: "ana", Date: yesterday}, startOfYesterday, endOfYesterday)
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 60, Owner: "bob", Date: yesterday}, startOfYesterday, endOfYesterday)
+
+	if err := uc.ProcessYesterdayOvertime(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notifier.PolicyAlertsSent) != 1 {
+		t.Fatalf("Expected one policy alert, got %d", len(notifier.PolicyAlertsSent))
+	}
+	alert := notifier.PolicyAlertsSent[0]
+	if alert.Manager != "manager@example.com" || len(alert.Violations) != 2 {
+		t.Fatalf("Expected the daily and monthly violations sent to the manager, got %+v", alert)
+	}
+	if alert.Violations[0].Kind != entities.ViolationDaily || alert.Violations[1].Kind != entities.ViolationMonthly || alert.Violations[1].Minutes != 350 {
+		t.Errorf("Expected ana's daily and monthly violations, got %+v", alert.Violations)
+	}
+}
+
+func TestGenerateMonthlyReportListsViolations(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier,
+		usecases.WithOvertimeLimits(entities.OvertimeLimits{DailyMinutes: 120}))
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	report := entities.NewOvertimeReport(entities.PeriodFor(prevMonth))
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 180, Owner: "ana", Date: prevMonth})
+	repo.AddTestReport(report)
+
+	if err := uc.GenerateMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notifier.LastReportSent.Violations) != 1 || notifier.LastReportSent.Violations[0].Excess() != 60 {
+		t.Errorf("Expected one daily violation of 60 minutes, got %+v", notifier.LastReportSent.Violations)
+	}
+
+	// Alerts are opt-in
+	if len(notifier.PolicyAlertsSent) != 0 {
+		t.Errorf("Expected no policy alert without WithPolicyAlerts, got %d", len(notifier.PolicyAlertsSent))
+	}
+}
+
+func TestGenerateMonthlyReportIncludesOnlyApprovedEntries(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+	approvals := mocks.NewMockApprovalRepository()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithApprovals(approvals))
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	report := entities.NewOvertimeReport(entities.PeriodFor(prevMonth))
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 60, Date: prevMonth, Source: "overtime-1"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: 30, Date: prevMonth, Source: "overtime-2"})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket3", Minutes: 45, Date: prevMonth, Source: "overtime-3"})
+	repo.AddTestReport(report)
+
+	ctx := context.Background()
+	if err := uc.ApproveEntries(ctx, "overtime-1", "manager"); err != nil {
+		t.Fatalf("Expected no error approving, got %v", err)
+	}
+	if err := uc.RejectEntries(ctx, "overtime-2", "manager", "duplicated"); err != nil {
+		t.Fatalf("Expected no error rejecting, got %v", err)
+	}
+	if approvals.Approvals["overtime-2"].Reason != "duplicated" || approvals.Approvals["overtime-2"].DecidedAt.IsZero() {
+		t.Errorf("Expected the rejection to be recorded with its reason and time, got %+v", approvals.Approvals["overtime-2"])
+	}
+
+	if err := uc.GenerateMonthlyReport(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sent := notifier.LastReportSent
+	if len(sent.Entries) != 1 || sent.TotalTime != 60 {
+		t.Errorf("Expected only the approved entry in the report, got %+v", sent.Entries)
+	}
+	if len(sent.Pending) != 1 || sent.Pending[0].Source != "overtime-3" {
+		t.Errorf("Expected the undecided entry listed as pending, got %+v", sent.Pending)
+	}
+}
+
+func TestRejectEntriesRequiresReason(t *testing.T) {
+	approvals := mocks.NewMockApprovalRepository()
+	uc := usecases.NewOvertimeUseCase(mocks.NewMockOvertimeRepository(), mocks.NewMockReportExporter(), mocks.NewMockNotificationService(),
+		usecases.WithApprovals(approvals))
+
+	if err := uc.RejectEntries(context.Background(), "overtime-1", "manager", " "); err == nil {
+		t.Error("Expected an error rejecting without a reason")
+	}
+	if len(approvals.Approvals) != 0 {
+		t.Errorf("Expected no approval recorded, got %+v", approvals.Approvals)
+	}
+
+	// Approvals must be enabled to record decisions
+	uc = usecases.NewOvertimeUseCase(mocks.NewMockOvertimeRepository(), mocks.NewMockReportExporter(), mocks.NewMockNotificationService())
+	if err := uc.ApproveEntries(context.Background(), "overtime-1", "manager"); err == nil {
+		t.Error("Expected an error approving without an approval repository")
+	}
+}
+
+func TestProcessYesterdayOvertimeQuarantinesInvalidEntries(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithDailySummary(true))
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	startOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location())
+	endOfYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, yesterday.Location())
+
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket1", Minutes: 40, Date: yesterday, Source: "overtime-1"}, startOfYesterday, endOfYesterday)
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "http://jira.com/ticket2", Minutes: -30, Date: yesterday, Source: "overtime-2", Line: 1}, startOfYesterday, endOfYesterday)
+	repo.AddTestEntry(entities.OvertimeEntry{TicketURL: "jira ticket", Minutes: 30, Date: yesterday, Source: "overtime-3", Line: 1}, startOfYesterday, endOfYesterday)
+	repo.AddTestMalformedEntry(entities.QuarantinedEntry{Source: "overtime-4", Line: 2, Reason: `minutes "abc" is not a number`}, startOfYesterday, endOfYesterday)
+
+	if err := uc.ProcessYesterdayOvertime(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Only the valid entry is merged
+	report, err := repo.GetMergedReport(context.Background(), entities.PeriodFor(now))
+	if err != nil {
+		t.Fatalf("Expected merged report, got %v", err)
+	}
+	if len(report.Entries) != 1 || report.TotalTime != 40 {
+		t.Errorf("Expected only the valid entry merged, got %+v", report.Entries)
+	}
+
+	// Malformed and invalid entries are recorded and reported in the daily summary
+	if len(repo.Quarantined) != 3 {
+		t.Fatalf("Expected three quarantined entries, got %+v", repo.Quarantined)
+	}
+	sources := map[string]bool{}
+	for _, entry := range repo.Quarantined {
+		sources[entry.Source] = true
+	}
+	if !sources["overtime-2"] || !sources["overtime-3"] || !sources["overtime-4"] {
+		t.Errorf("Expected the invalid sources quarantined, got %+v", repo.Quarantined)
+	}
+	if len(notifier.LastDailySummary.Quarantined) != 3 {
+		t.Errorf("Expected the quarantined entries in the daily summary, got %+v", notifier.LastDailySummary.Quarantined)
+	}
+}
+
+func TestGenerateMonthlyReportEnrichesTickets(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	exporter := mocks.NewMockReportExporter()
+	notifier := mocks.NewMockNotificationService()
+	tickets := mocks.NewMockTicketRepository()
+	tickets.Tickets["OPS-12"] = entities.TicketInfo{Key: "OPS-12", Title: "Database failover", Project: "Operations", Client: "Acme"}
+
+	uc := usecases.NewOvertimeUseCase(repo, exporter, notifier, usecases.WithTicketEnrichment(tickets),
+		usecases.WithJiraBaseURL("https://acme.atlassian.net/"))
+
+	prevMonth := time.Now().AddDate(0, -1, 0)
+	report := entities.NewOvertimeReport(entities.PeriodFor(prevMonth))
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-12", Minutes: 60, Date: prevMonth})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-99", Minutes: 30, Date: prevMonth})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-99", Minutes: 15, Date: prevMonth})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "http://intranet/incident", Minutes: 45, Date: prevMonth})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://partner.atlassian.net/browse/OPS-12", Minutes: 20, Date: prevMonth})
+	repo.AddTestReport(report)
+
+	// A ticket that cannot be fetched must not fail the report
+	if err := uc.GenerateMonthlyReport(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sent := notifier.LastReportSent.Entries
+	if sent[0].Ticket.Title != "Database failover" || sent[0].Ticket.Client != "Acme" {
+		t.Errorf("Expected the first entry enriched, got %+v", sent[0].Ticket)
+	}
+	if sent[1].Ticket.Key != "" || sent[3].Ticket.Key != "" || sent[4].Ticket.Key != "" {
+		t.Errorf("Expected unknown tickets left as URLs, got %+v, %+v and %+v", sent[1].Ticket, sent[3].Ticket, sent[4].Ticket)
+	}
+
+	// Failed tickets are not requested again, URLs without a key and tickets of other Jira
+	// installations are not requested at all
+	if len(tickets.Requests) != 2 {
+		t.Errorf("Expected two ticket requests, got %v", tickets.Requests)
+	}
+}
+
+func TestImportEntries(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	uc := usecases.NewOvertimeUseCase(repo, mocks.NewMockReportExporter(), mocks.NewMockNotificationService(),
+		usecases.WithEntryRules(entities.EntryRules{MaxMinutes: 240}))
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 15, 10, 0, 0, 0, time.UTC)
+	tracked := []entities.TrackedEntry{
+		{Start: start, End: start.Add(2 * time.Hour), Tags: []string{"overtime"}, Description: "https://acme.atlassian.net/browse/OPS-1/", Line: 1},
+		{Start: start, End: start.Add(5 * time.Hour), Tags: []string{"overtime"}, Description: "https://acme.atlassian.net/browse/OPS-2", Line: 2},
+		{Start: start.AddDate(0, -1, 0), End: start.AddDate(0, -1, 0).Add(time.Hour), Tags: []string{"overtime"}, Description: "https://acme.atlassian.net/browse/OPS-3", Line: 3},
+	}
+	rules := entities.ImportRules{WorkingHours: entities.DefaultWorkingHours, OvertimeTags: []string{"overtime"}}
+
+	// Previews save nothing
+	result, err := uc.ImportEntries(context.Background(), tracked, rules, false)
+	if err != nil {
+		t.Fatalf("Error previewing import: %v", err)
+	}
+	if len(result.Entries) != 1 || len(result.Skipped) != 2 || len(repo.Saved) != 0 {
+		t.Fatalf("Expected one entry, two skipped and nothing saved, got %+v and %d saves", result, len(repo.Saved))
+	}
+	// Entries of a past month would be merged into the current report
+	if !strings.Contains(result.Skipped[1].Reason, "open period "+entities.PeriodFor(now)) {
+		t.Errorf("Expected the entry of last month skipped, got %q", result.Skipped[1].Reason)
+	}
+	if result.Entries[0].TicketURL != "https://acme.atlassian.net/browse/OPS-1" {
+		t.Errorf("Expected the canonical ticket URL, got %q", result.Entries[0].TicketURL)
+	}
+
+	result, err = uc.ImportEntries(context.Background(), tracked, rules, true)
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if len(repo.Saved) != 1 || len(repo.Saved[0]) != 1 || result.Source != "overtime-import-1" {
+		t.Errorf("Expected the valid entry saved once, got %+v in %q", repo.Saved, result.Source)
+	}
+}
+
+func TestImportEntriesSkipsImportedEvents(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	uc := usecases.NewOvertimeUseCase(repo, mocks.NewMockReportExporter(), mocks.NewMockNotificationService())
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 15, 22, 0, 0, 0, time.UTC)
+	event := entities.TrackedEntry{Start: start, End: start.Add(time.Hour), URL: "https://acme.atlassian.net/browse/OPS-7", ID: "incident-1", Line: 1}
+	rules := entities.ImportRules{AllOvertime: true}
+
+	result, err := uc.ImportEntries(context.Background(), []entities.TrackedEntry{event, event}, rules, true)
+	if err != nil {
+		t.Fatalf("Error importing events: %v", err)
+	}
+	if len(result.Entries) != 1 || len(result.Skipped) != 1 || result.Entries[0].ExternalID != "incident-1" {
+		t.Fatalf("Expected the repeated event skipped, got %+v", result)
+	}
+
+	// Importing the calendar again saves nothing
+	result, err = uc.ImportEntries(context.Background(), []entities.TrackedEntry{event}, rules, true)
+	if err != nil {
+		t.Fatalf("Error importing events again: %v", err)
+	}
+	if len(result.Entries) != 0 || len(repo.Saved) != 1 || !strings.Contains(result.Skipped[0].Reason, "overtime-import-1") {
+		t.Errorf("Expected the event skipped as already imported, got %+v", result)
+	}
+}
+
+func TestSuggestOvertimeComparesLoggedEntries(t *testing.T) {
+	repo := mocks.NewMockOvertimeRepository()
+	uc := usecases.NewOvertimeUseCase(repo, mocks.NewMockReportExporter(), mocks.NewMockNotificationService())
+
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-7", Minutes: 60, Owner: "Ana@example.com",
+		Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)})
+	report.AppendEntry(entities.OvertimeEntry{TicketURL: "https://acme.atlassian.net/browse/OPS-8", Minutes: 120, Owner: "bruno@example.com",
+		Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)})
+	repo.AddTestReport(report)
+
+	commits := []entities.Commit{{Repository: "api", Time: time.Date(2026, time.September, 1, 21, 0, 0, 0, time.UTC), Message: "Fix OPS-7"}}
+	rules := entities.SuggestRules{WorkingHours: entities.DefaultWorkingHours}
+
+	suggestion, err := uc.SuggestOvertime(context.Background(), commits, rules, "ana@example.com", "Sep-2026", true)
+	if err != nil {
+		t.Fatalf("Error suggesting overtime: %v", err)
+	}
+	if len(suggestion.Entries) != 1 || suggestion.Entries[0].TicketURL != "OPS-7" || suggestion.Entries[0].Minutes != 30 {
+		t.Errorf("Expected a 30 minute proposal on OPS-7, got %+v", suggestion.Entries)
+	}
+	if len(suggestion.Days) != 1 || suggestion.Days[0].Logged != 60 || suggestion.Days[0].Difference() != -30 {
+		t.Errorf("Expected only the entries of the owner compared, got %+v", suggestion.Days)
+	}
+}
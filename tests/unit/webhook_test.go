@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MateSousa/overtime-script/pkg/adapters/notification"
+	"github.com/MateSousa/overtime-script/pkg/domain/entities"
+	"github.com/MateSousa/overtime-script/pkg/domain/repositories"
+)
+
+// webhookRecorder is a local HTTP stand-in that records the last request it received
+type webhookRecorder struct {
+	server  *httptest.Server
+	status  int
+	body    []byte
+	headers http.Header
+	calls   int
+}
+
+func newWebhookRecorder(t *testing.T) *webhookRecorder {
+	rec := &webhookRecorder{status: http.StatusOK}
+	rec.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Error reading request body: %v", err)
+		}
+		rec.calls++
+		rec.body = body
+		rec.headers = r.Header.Clone()
+		w.WriteHeader(rec.status)
+	}))
+	t.Cleanup(rec.server.Close)
+	return rec
+}
+
+func newTestReport() *entities.OvertimeReport {
+	report := entities.NewOvertimeReport("Sep-2026")
+	report.AddEntry("http://jira.com/ticket1", 90)
+	report.AddEntry("http://jira.com/ticket2", 30)
+	return report
+}
+
+func TestSlackWebhookService(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	service := notification.NewSlackWebhookService(rec.server.URL)
+
+	_, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "/tmp/overtime.xlsx"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var message struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(rec.body, &message); err != nil {
+		t.Fatalf("Error decoding Slack payload: %v", err)
+	}
+
+	for _, expected := range []string{"Sep-2026", "120 minutos", "http://jira.com/ticket1", "overtime.xlsx"} {
+		if !strings.Contains(message.Text, expected) {
+			t.Errorf("Expected Slack message to contain %q, got %q", expected, message.Text)
+		}
+	}
+}
+
+func TestSlackWebhookServiceDurationFormat(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	format := entities.DurationFormat{Unit: entities.DurationHoursMinutes}
+	service := notification.NewSlackWebhookService(rec.server.URL, notification.WithDurationFormat(format))
+
+	if _, err := service.SendReportByEmail(context.Background(), newTestReport(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var message struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(rec.body, &message); err != nil {
+		t.Fatalf("Error decoding Slack payload: %v", err)
+	}
+
+	for _, expected := range []string{"Total: 02:00 horas", "http://jira.com/ticket1: 01:30 horas"} {
+		if !strings.Contains(message.Text, expected) {
+			t.Errorf("Expected Slack message to contain %q, got %q", expected, message.Text)
+		}
+	}
+}
+
+func TestTeamsWebhookService(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	service := notification.NewTeamsWebhookService(rec.server.URL)
+
+	_, err := service.SendReportByEmail(context.Background(), newTestReport(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var card map[string]string
+	if err := json.Unmarshal(rec.body, &card); err != nil {
+		t.Fatalf("Error decoding Teams payload: %v", err)
+	}
+
+	if card["@type"] != "MessageCard" {
+		t.Errorf("Expected MessageCard type, got %q", card["@type"])
+	}
+
+	if !strings.Contains(card["title"], "Sep-2026") {
+		t.Errorf("Expected title to contain the period, got %q", card["title"])
+	}
+}
+
+func TestGenericWebhookServiceSignsPayload(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	headers := map[string]string{"Authorization": "Bearer token"}
+	service := notification.NewGenericWebhookService(rec.server.URL, headers, "secret")
+
+	_, err := service.SendReportByEmail(context.Background(), newTestReport(), []entities.Attachment{{Path: "/tmp/overtime.xlsx"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.headers.Get("Authorization") != "Bearer token" {
+		t.Errorf("Expected custom header to be sent, got %q", rec.headers.Get("Authorization"))
+	}
+
+	expectedSignature := "sha256=" + notification.SignPayload("secret", rec.body)
+	if rec.headers.Get(notification.SignatureHeader) != expectedSignature {
+		t.Errorf("Expected signature %s, got %s", expectedSignature, rec.headers.Get(notification.SignatureHeader))
+	}
+
+	var payload notification.WebhookPayload
+	if err := json.Unmarshal(rec.body, &payload); err != nil {
+		t.Fatalf("Error decoding webhook payload: %v", err)
+	}
+
+	if payload.Event != notification.EventMonthlyReport {
+		t.Errorf("Expected event %s, got %s", notification.EventMonthlyReport, payload.Event)
+	}
+
+	if payload.TotalMinutes != 120 || len(payload.Entries) != 2 {
+		t.Errorf("Expected 2 entries totalling 120 minutes, got %d entries and %d minutes", len(payload.Entries), payload.TotalMinutes)
+	}
+
+	if len(payload.Attachments) != 1 || payload.Attachments[0] != "overtime.xlsx" {
+		t.Errorf("Expected attachment name overtime.xlsx, got %v", payload.Attachments)
+	}
+}
+
+func TestGenericWebhookServiceDailySummary(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	service := notification.NewGenericWebhookService(rec.server.URL, nil, "")
+
+	day := time.Date(2026, time.September, 14, 0, 0, 0, 0, time.UTC)
+	summary := &entities.DailySummary{
+		Date:        day,
+		Entries:     []entities.OvertimeEntry{{TicketURL: "http://jira.com/ticket1", Minutes: 45, Date: day}},
+		MonthReport: newTestReport(),
+	}
+
+	notifier, ok := service.(repositories.DailySummaryNotifier)
+	if !ok {
+		t.Fatal("Expected generic webhook service to support daily summaries")
+	}
+
+	if err := notifier.SendDailySummary(context.Background(), summary); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.headers.Get(notification.SignatureHeader) != "" {
+		t.Error("Expected no signature header without a secret")
+	}
+
+	var payload notification.WebhookPayload
+	if err := json.Unmarshal(rec.body, &payload); err != nil {
+		t.Fatalf("Error decoding webhook payload: %v", err)
+	}
+
+	if payload.Event != notification.EventDailySummary || payload.Date != "2026-09-14" {
+		t.Errorf("Expected daily summary for 2026-09-14, got %s for %s", payload.Event, payload.Date)
+	}
+
+	if payload.TotalMinutes != 45 || payload.MonthMinutes != 120 {
+		t.Errorf("Expected 45 minutes of 120 in the month, got %d of %d", payload.TotalMinutes, payload.MonthMinutes)
+	}
+}
+
+func TestWebhookServiceErrorStatus(t *testing.T) {
+	rec := newWebhookRecorder(t)
+	rec.status = http.StatusInternalServerError
+	service := notification.NewSlackWebhookService(rec.server.URL)
+
+	_, err := service.SendReportByEmail(context.Background(), newTestReport(), nil)
+	if err == nil {
+		t.Fatal("Expected error for non-2xx status, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "500") {
+		t.Errorf("Expected error to mention the status code, got %v", err)
+	}
+}